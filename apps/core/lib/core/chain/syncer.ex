@@ -357,15 +357,15 @@ defmodule Core.Chain.Syncer do
   defp submit_blocks_batch(chain, blocks) do
     blocks
     |> Enum.reduce_while(:ok, fn
-      {:ok, block}, :ok ->
+      {:ok, %Ethereum.BlockData{block: block} = block_data}, :ok ->
         case Core.DataProcessor.WorkerPool.submit_task(
                Core.DataProcessor.WorkerPool,
                chain,
                :block,
                %{
-                 number: block.number,
+                 number: block.block_number,
                  hash: block.hash,
-                 data: block
+                 data: block_data
                }
              ) do
           :ok -> {:cont, :ok}
@@ -123,14 +123,16 @@ defmodule Core.GRPC.EthereumClient do
     end
   end
 
-  defp build_latest_block_request(), do: %Google.Protobuf.Empty{}
+  defp build_latest_block_request(), do: %Ethereum.GetLatestBlockRequest{}
 
   defp build_block_request(block_number) do
     %Ethereum.GetBlockRequest{block_number: block_number}
   end
 
+  # Blocks that cannot be fetched are reported as block_error events and
+  # recorded as missing, instead of ending the stream.
   defp build_subscription_request(start_block) do
-    %Ethereum.SubscribeNewBlocksRequest{start_block: start_block}
+    %Ethereum.SubscribeNewBlocksRequest{start_block: start_block, report_errors: true}
   end
 
   defp execute_with_retry(fun) do
@@ -156,8 +158,17 @@ defmodule Core.GRPC.EthereumClient do
   defp process_subscription(stream, callback) do
     stream
     |> Stream.each(fn
-      {:ok, block} -> execute_callback_safely(callback, block)
-      {:error, error} -> Logger.error("Stream error: #{inspect(error)}")
+      {:ok, %Ethereum.BlockEvent{event: {:block_data, block}}} ->
+        execute_callback_safely(callback, block)
+
+      {:ok, %Ethereum.BlockEvent{event: {:block_removed, removed}}} ->
+        handle_block_removed(removed)
+
+      {:ok, %Ethereum.BlockEvent{event: {:block_error, block_error}}} ->
+        handle_block_error(block_error)
+
+      {:error, error} ->
+        Logger.error("Stream error: #{inspect(error)}")
     end)
     |> Stream.run()
   rescue
@@ -181,16 +192,36 @@ defmodule Core.GRPC.EthereumClient do
     end
   end
 
+  defp handle_block_removed(removed) do
+    Logger.warning("Block #{removed.block_number} (#{removed.hash}) removed by chain reorg")
+
+    :telemetry.execute(
+      @telemetry_prefix ++ [:block_removed],
+      %{count: 1},
+      %{block_number: removed.block_number, hash: removed.hash}
+    )
+  end
+
+  defp handle_block_error(block_error) do
+    Logger.warning("Block #{block_error.block_number} could not be fetched: #{block_error.reason}")
+
+    Core.Schema.MissingBlock.record_failure(:ethereum, block_error.block_number, "fetch_failed",
+      error_message: block_error.reason,
+      metadata: %{retryable: block_error.retryable}
+    )
+  end
+
   defp init_range_stream(start_block, end_block) do
     request = %Ethereum.GetBlockRangeRequest{
       start_block: start_block,
-      end_block: end_block
+      end_block: end_block,
+      report_errors: true
     }
 
     case get_channel() do
       {:ok, channel} ->
         case Ethereum.EthereumService.Stub.get_block_range(channel, request) do
-          {:ok, stream} -> {:ok, stream, System.monotonic_time()}
+          {:ok, stream} -> {:ok, Stream.flat_map(stream, &unwrap_range_event/1), System.monotonic_time()}
           error -> {:error, error}
         end
 
@@ -201,8 +232,8 @@ defmodule Core.GRPC.EthereumClient do
 
   defp process_range_stream({:ok, stream, start_time}) do
     case GRPC.Stub.recv(stream) do
-      {:ok, block} ->
-        {[{:ok, block}], {:ok, stream, start_time}}
+      {:ok, event} ->
+        {unwrap_range_event({:ok, event}), {:ok, stream, start_time}}
 
       {:error, error} ->
         report_stream_error(error, start_time)
@@ -212,6 +243,19 @@ defmodule Core.GRPC.EthereumClient do
 
   defp process_range_stream(error), do: {:halt, error}
 
+  # Range streams carry BlockEvents; consumers only see the blocks. Blocks
+  # that could not be fetched are recorded as missing and skipped.
+  defp unwrap_range_event({:ok, %Ethereum.BlockEvent{event: {:block_data, block}}}),
+    do: [{:ok, block}]
+
+  defp unwrap_range_event({:ok, %Ethereum.BlockEvent{event: {:block_error, block_error}}}) do
+    handle_block_error(block_error)
+    []
+  end
+
+  defp unwrap_range_event({:ok, %Ethereum.BlockEvent{}}), do: []
+  defp unwrap_range_event({:error, _} = error), do: [error]
+
   defp cleanup_range_stream({:ok, stream, _}), do: GRPC.Stub.end_stream(stream)
   defp cleanup_range_stream({:error, stream}), do: GRPC.Stub.end_stream(stream)
   defp cleanup_range_stream(_), do: :ok
@@ -1,3 +1,22 @@
+defmodule Ethereum.BlockConfirmation do
+  @moduledoc false
+
+  use Protobuf, enum: true, protoc_gen_elixir_version: "0.14.0", syntax: :proto3
+
+  field :BLOCK_CONFIRMATION_LATEST, 0
+  field :BLOCK_CONFIRMATION_SAFE, 1
+  field :BLOCK_CONFIRMATION_FINALIZED, 2
+end
+
+defmodule Ethereum.GetLatestBlockRequest do
+  @moduledoc false
+
+  use Protobuf, protoc_gen_elixir_version: "0.14.0", syntax: :proto3
+
+  field :confirmation, 1, type: Ethereum.BlockConfirmation, enum: true
+  field :confirmations, 2, type: :uint32
+end
+
 defmodule Ethereum.GetLatestBlockResponse do
   @moduledoc false
 
@@ -12,6 +31,7 @@ defmodule Ethereum.GetBlockRequest do
   use Protobuf, protoc_gen_elixir_version: "0.14.0", syntax: :proto3
 
   field :block_number, 1, type: :int64, json_name: "blockNumber"
+  field :quorum, 2, type: :bool
 end
 
 defmodule Ethereum.GetBlockResponse do
@@ -28,6 +48,9 @@ defmodule Ethereum.SubscribeNewBlocksRequest do
   use Protobuf, protoc_gen_elixir_version: "0.14.0", syntax: :proto3
 
   field :start_block, 1, type: :int64, json_name: "startBlock"
+  field :confirmation, 2, type: Ethereum.BlockConfirmation, enum: true
+  field :confirmations, 3, type: :uint32
+  field :report_errors, 4, type: :bool, json_name: "reportErrors"
 end
 
 defmodule Ethereum.GetBlockRangeRequest do
@@ -37,6 +60,8 @@ defmodule Ethereum.GetBlockRangeRequest do
 
   field :start_block, 1, type: :int64, json_name: "startBlock"
   field :end_block, 2, type: :int64, json_name: "endBlock"
+  field :report_errors, 3, type: :bool, json_name: "reportErrors"
+  field :quorum, 4, type: :bool
 end
 
 defmodule Ethereum.EthereumService.Service do
@@ -44,13 +69,13 @@ defmodule Ethereum.EthereumService.Service do
 
   use GRPC.Service, name: "ethereum.EthereumService", protoc_gen_elixir_version: "0.14.0"
 
-  rpc :GetLatestBlock, Google.Protobuf.Empty, Ethereum.GetLatestBlockResponse
+  rpc :GetLatestBlock, Ethereum.GetLatestBlockRequest, Ethereum.GetLatestBlockResponse
 
   rpc :GetBlock, Ethereum.GetBlockRequest, Ethereum.GetBlockResponse
 
-  rpc :SubscribeNewBlocks, Ethereum.SubscribeNewBlocksRequest, stream(Ethereum.BlockData)
+  rpc :SubscribeNewBlocks, Ethereum.SubscribeNewBlocksRequest, stream(Ethereum.BlockEvent)
 
-  rpc :GetBlockRange, Ethereum.GetBlockRangeRequest, stream(Ethereum.BlockData)
+  rpc :GetBlockRange, Ethereum.GetBlockRangeRequest, stream(Ethereum.BlockEvent)
 end
 
 defmodule Ethereum.EthereumService.Stub do
@@ -92,3 +92,35 @@ defmodule Ethereum.BlockData do
     type: Ethereum.TokenTransfer,
     json_name: "tokenTransfers"
 end
+
+defmodule Ethereum.BlockRemoved do
+  @moduledoc false
+
+  use Protobuf, protoc_gen_elixir_version: "0.14.0", syntax: :proto3
+
+  field :block_number, 1, type: :int64, json_name: "blockNumber"
+  field :hash, 2, type: :string
+  field :parent_hash, 3, type: :string, json_name: "parentHash"
+end
+
+defmodule Ethereum.BlockError do
+  @moduledoc false
+
+  use Protobuf, protoc_gen_elixir_version: "0.14.0", syntax: :proto3
+
+  field :block_number, 1, type: :int64, json_name: "blockNumber"
+  field :reason, 2, type: :string
+  field :retryable, 3, type: :bool
+end
+
+defmodule Ethereum.BlockEvent do
+  @moduledoc false
+
+  use Protobuf, protoc_gen_elixir_version: "0.14.0", syntax: :proto3
+
+  oneof :event, 0
+
+  field :block_data, 1, type: Ethereum.BlockData, json_name: "blockData", oneof: 0
+  field :block_removed, 2, type: Ethereum.BlockRemoved, json_name: "blockRemoved", oneof: 0
+  field :block_error, 3, type: Ethereum.BlockError, json_name: "blockError", oneof: 0
+end
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/al002/sylph/chains/ethereum/pkg/rpc"
+)
+
+// registerAdminRoutes adds the endpoint admin API to mux. Every request must
+// carry "Authorization: Bearer <token>". Endpoints are identified by their
+// URL without fragment options, passed as the url query parameter:
+//
+//	POST   /admin/endpoints?url=...          add an endpoint (options allowed)
+//	DELETE /admin/endpoints?url=...          remove an endpoint
+//	POST   /admin/endpoints/disable?url=...  stop routing calls to an endpoint
+//	POST   /admin/endpoints/enable?url=...   put an endpoint back into rotation
+//	POST   /admin/endpoints/drain?url=...    disable and wait for in-flight calls
+func registerAdminRoutes(mux *http.ServeMux, client *rpc.Client, token string) {
+	handle := func(pattern string, fn func(r *http.Request, url string) error) {
+		mux.HandleFunc(pattern, func(w http.ResponseWriter, r *http.Request) {
+			auth, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+			if !ok || subtle.ConstantTimeCompare([]byte(auth), []byte(token)) != 1 {
+				http.Error(w, "unauthorized", http.StatusUnauthorized)
+				return
+			}
+
+			url := r.URL.Query().Get("url")
+			if url == "" {
+				http.Error(w, "missing url parameter", http.StatusBadRequest)
+				return
+			}
+
+			if err := fn(r, url); err != nil {
+				code := http.StatusBadRequest
+				if errors.Is(err, rpc.ErrEndpointNotFound) {
+					code = http.StatusNotFound
+				}
+				http.Error(w, err.Error(), code)
+				return
+			}
+
+			json.NewEncoder(w).Encode(client.HealthStatus())
+		})
+	}
+
+	handle("POST /admin/endpoints", func(r *http.Request, url string) error {
+		return client.AddEndpoint(url)
+	})
+	handle("DELETE /admin/endpoints", func(r *http.Request, url string) error {
+		return client.RemoveEndpoint(url)
+	})
+	handle("POST /admin/endpoints/disable", func(r *http.Request, url string) error {
+		return client.DisableEndpoint(url)
+	})
+	handle("POST /admin/endpoints/enable", func(r *http.Request, url string) error {
+		return client.EnableEndpoint(url)
+	})
+	handle("POST /admin/endpoints/drain", func(r *http.Request, url string) error {
+		return client.DrainEndpoint(r.Context(), url)
+	})
+}
@@ -36,17 +36,14 @@ func main() {
 		cfg.GRPCServerPort = *portOverride
 	}
 
-	client, err := rpc.NewClient(
-		cfg.HTTPEndpoints,
-		cfg.WSEndpoints,
-	)
+	client, err := rpc.NewClient(cfg)
 	if err != nil {
 		log.Fatalf("Failed to create Ethereum rpc client: %v", err)
 	}
 	defer client.Close()
 
 	grpcServer := grpc.NewServer()
-	ethService, err := service.NewEthereumService(client)
+	ethService, err := service.NewEthereumService(client, cfg)
 
 	if err != nil {
 		log.Fatalf("Failed to create Ethereum service: %v", err)
@@ -71,9 +68,14 @@ func main() {
 		mux := http.NewServeMux()
 		mux.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
 			status := client.HealthStatus()
+			status["cache"] = ethService.CacheStatus()
 			json.NewEncoder(w).Encode(status)
 		})
 
+		if cfg.AdminToken != "" {
+			registerAdminRoutes(mux, client, cfg.AdminToken)
+		}
+
 		healthAddr := fmt.Sprintf(":%d", cfg.GRPCServerPort+1)
 		log.Printf("Starting health check server on %s", healthAddr)
 		if err := http.ListenAndServe(healthAddr, mux); err != nil {
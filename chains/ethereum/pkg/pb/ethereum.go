@@ -18,10 +18,32 @@ type (
 	Log           = pb.Log
 	TokenTransfer = pb.TokenTransfer
 	BlockData     = pb.BlockData
+	BlockRemoved  = pb.BlockRemoved
+	BlockError    = pb.BlockError
+	BlockEvent    = pb.BlockEvent
+)
+
+// Block event variants
+type (
+	BlockEvent_BlockData    = pb.BlockEvent_BlockData
+	BlockEvent_BlockRemoved = pb.BlockEvent_BlockRemoved
+	BlockEvent_BlockError   = pb.BlockEvent_BlockError
+)
+
+// Enum types
+type (
+	BlockConfirmation = pb.BlockConfirmation
+)
+
+const (
+	BlockConfirmation_BLOCK_CONFIRMATION_LATEST    = pb.BlockConfirmation_BLOCK_CONFIRMATION_LATEST
+	BlockConfirmation_BLOCK_CONFIRMATION_SAFE      = pb.BlockConfirmation_BLOCK_CONFIRMATION_SAFE
+	BlockConfirmation_BLOCK_CONFIRMATION_FINALIZED = pb.BlockConfirmation_BLOCK_CONFIRMATION_FINALIZED
 )
 
 // Request/Response types
 type (
+	GetLatestBlockRequest     = pb.GetLatestBlockRequest
 	GetLatestBlockResponse    = pb.GetLatestBlockResponse
 	GetBlockRequest           = pb.GetBlockRequest
 	GetBlockResponse          = pb.GetBlockResponse
@@ -0,0 +1,196 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/al002/sylph/chains/ethereum/pkg/config"
+)
+
+const (
+	// drainPollInterval is how often a draining endpoint is checked for
+	// calls still in flight.
+	drainPollInterval = 100 * time.Millisecond
+	// retireTimeout bounds how long a removed endpoint is kept open for its
+	// in-flight calls.
+	retireTimeout = time.Minute
+)
+
+var ErrEndpointNotFound = errors.New("endpoint not found")
+
+// AddEndpoint dials a new endpoint and adds it to the HTTP or WS list
+// depending on its scheme; IPC endpoints go to the HTTP list. raw may carry
+// fragment options like the configured endpoints. The endpoint is health
+// checked before it is added, so it takes calls straight away if it is
+// reachable.
+func (c *Client) AddEndpoint(raw string) error {
+	ep, err := config.ParseEndpoint(raw)
+	if err != nil {
+		return err
+	}
+
+	if err := ep.Validate("http://", "https://", "ws://", "wss://"); err != nil {
+		return err
+	}
+	ws := strings.HasPrefix(ep.URL, "ws://") || strings.HasPrefix(ep.URL, "wss://")
+
+	if hc, _ := c.findEndpoint(ep.URL); hc != nil {
+		return fmt.Errorf("endpoint already exists: %s", ep.Redacted())
+	}
+
+	hc, err := newHealthyClient(raw, c.cfg)
+	if err == nil {
+		err = hc.dial()
+	}
+	if err != nil {
+		return fmt.Errorf("failed to dial endpoint %v", err)
+	}
+	c.checkClientsHealth([]*HealthyClient{hc})
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// Checked again in case the same endpoint was added while dialing.
+	if existing, _ := c.findEndpointLocked(ep.URL); existing != nil {
+		hc.close()
+		return fmt.Errorf("endpoint already exists: %s", ep.Redacted())
+	}
+
+	if ws {
+		c.wsClients = append(slices.Clone(c.wsClients), hc)
+	} else {
+		c.httpClients = append(slices.Clone(c.httpClients), hc)
+	}
+
+	log.Printf("Endpoint %s added (healthy: %v)", hc.endpoint, hc.isHealthy.Load())
+	return nil
+}
+
+// RemoveEndpoint takes an endpoint out of its list. Calls already running
+// against it may finish; the connection is closed once they have, or after
+// retireTimeout. Subscriptions on a removed WS endpoint end with an error.
+func (c *Client) RemoveEndpoint(url string) error {
+	c.mu.Lock()
+	hc, ws := c.findEndpointLocked(url)
+	if hc == nil {
+		c.mu.Unlock()
+		return fmt.Errorf("%w: %s", ErrEndpointNotFound, url)
+	}
+
+	if !ws && len(c.httpClients) == 1 {
+		c.mu.Unlock()
+		return fmt.Errorf("cannot remove the last HTTP endpoint: %s", url)
+	}
+
+	remove := func(clients []*HealthyClient) []*HealthyClient {
+		return slices.DeleteFunc(slices.Clone(clients), func(other *HealthyClient) bool { return other == hc })
+	}
+	if ws {
+		c.wsClients = remove(c.wsClients)
+	} else {
+		c.httpClients = remove(c.httpClients)
+	}
+	c.mu.Unlock()
+
+	hc.draining.Store(true)
+	log.Printf("Endpoint %s removed", hc.endpoint)
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), retireTimeout)
+		defer cancel()
+
+		if err := waitDrained(ctx, hc); err != nil {
+			log.Printf("Closing endpoint %s with %d calls in flight", hc.endpoint, hc.inflight.Load())
+		}
+		hc.close()
+	}()
+
+	return nil
+}
+
+// DisableEndpoint stops routing calls to an endpoint until it is enabled
+// again. Calls already running against it are not affected.
+func (c *Client) DisableEndpoint(url string) error {
+	hc, _ := c.findEndpoint(url)
+	if hc == nil {
+		return fmt.Errorf("%w: %s", ErrEndpointNotFound, url)
+	}
+
+	hc.disabled.Store(true)
+	log.Printf("Endpoint %s disabled", hc.endpoint)
+	return nil
+}
+
+// EnableEndpoint puts a disabled or drained endpoint back into rotation.
+func (c *Client) EnableEndpoint(url string) error {
+	hc, _ := c.findEndpoint(url)
+	if hc == nil {
+		return fmt.Errorf("%w: %s", ErrEndpointNotFound, url)
+	}
+
+	hc.disabled.Store(false)
+	hc.draining.Store(false)
+	log.Printf("Endpoint %s enabled", hc.endpoint)
+	return nil
+}
+
+// DrainEndpoint stops routing new calls to an endpoint and waits until the
+// calls already running against it have finished or ctx is done. The
+// endpoint stays drained until it is enabled again.
+func (c *Client) DrainEndpoint(ctx context.Context, url string) error {
+	hc, _ := c.findEndpoint(url)
+	if hc == nil {
+		return fmt.Errorf("%w: %s", ErrEndpointNotFound, url)
+	}
+
+	hc.draining.Store(true)
+	log.Printf("Draining endpoint %s", hc.endpoint)
+
+	if err := waitDrained(ctx, hc); err != nil {
+		return fmt.Errorf("endpoint %s still has %d calls in flight: %w", hc.endpoint, hc.inflight.Load(), err)
+	}
+
+	log.Printf("Endpoint %s drained", hc.endpoint)
+	return nil
+}
+
+func waitDrained(ctx context.Context, hc *HealthyClient) error {
+	ticker := time.NewTicker(drainPollInterval)
+	defer ticker.Stop()
+
+	for hc.inflight.Load() > 0 {
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return nil
+}
+
+// findEndpoint returns the endpoint with the given URL and whether it is a
+// WS endpoint.
+func (c *Client) findEndpoint(url string) (*HealthyClient, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.findEndpointLocked(url)
+}
+
+func (c *Client) findEndpointLocked(url string) (*HealthyClient, bool) {
+	for _, hc := range c.httpClients {
+		if hc.url == url {
+			return hc, false
+		}
+	}
+	for _, hc := range c.wsClients {
+		if hc.url == url {
+			return hc, true
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,169 @@
+package rpc
+
+import (
+	"sync"
+	"time"
+)
+
+type breakerState int
+
+const (
+	breakerClosed breakerState = iota
+	breakerOpen
+	breakerHalfOpen
+)
+
+func (s breakerState) String() string {
+	switch s {
+	case breakerClosed:
+		return "closed"
+	case breakerOpen:
+		return "open"
+	case breakerHalfOpen:
+		return "half-open"
+	default:
+		return "unknown"
+	}
+}
+
+// circuitBreaker keeps traffic away from an endpoint whose calls keep
+// failing. It opens after failureThreshold consecutive failures, stays open
+// for openTimeout, and then lets up to halfOpenRequests trial calls through;
+// it closes once that many trials succeed and reopens on any failed trial.
+// Only call outcomes move the breaker, health probes do not.
+type circuitBreaker struct {
+	failureThreshold int
+	openTimeout      time.Duration
+	halfOpenRequests int
+
+	mu        sync.Mutex
+	state     breakerState
+	failures  int
+	openedAt  time.Time
+	gen       uint64 // incremented on every move to half-open
+	inFlight  int
+	successes int
+}
+
+func newCircuitBreaker(failureThreshold int, openTimeout time.Duration, halfOpenRequests int) *circuitBreaker {
+	return &circuitBreaker{
+		failureThreshold: failureThreshold,
+		openTimeout:      openTimeout,
+		halfOpenRequests: halfOpenRequests,
+	}
+}
+
+// available reports whether a call may be sent now, without reserving it.
+func (b *circuitBreaker) available() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case breakerOpen:
+		return time.Since(b.openedAt) >= b.openTimeout
+	case breakerHalfOpen:
+		return b.inFlight < b.halfOpenRequests
+	default:
+		return true
+	}
+}
+
+// breakerCall is a call admitted by acquire. Trial calls were admitted
+// while half-open, gen tells which half-open period they belong to, so
+// calls that outlive a state change do not count for the next one.
+type breakerCall struct {
+	trial bool
+	gen   uint64
+}
+
+// acquire admits a call, moving an open breaker whose timeout has passed to
+// half-open. It returns false if the breaker is open or all trial calls of
+// the half-open period are in flight. Every admitted call must be followed
+// by onSuccess, onFailure or release.
+func (b *circuitBreaker) acquire() (breakerCall, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == breakerOpen && time.Since(b.openedAt) >= b.openTimeout {
+		b.state = breakerHalfOpen
+		b.gen++
+		b.inFlight = 0
+		b.successes = 0
+	}
+
+	switch b.state {
+	case breakerOpen:
+		return breakerCall{}, false
+	case breakerHalfOpen:
+		if b.inFlight >= b.halfOpenRequests {
+			return breakerCall{}, false
+		}
+		b.inFlight++
+		return breakerCall{trial: true, gen: b.gen}, true
+	default:
+		return breakerCall{}, true
+	}
+}
+
+// isTrial reports whether call is a trial of the current half-open period.
+func (b *circuitBreaker) isTrial(call breakerCall) bool {
+	return call.trial && b.state == breakerHalfOpen && call.gen == b.gen
+}
+
+func (b *circuitBreaker) onSuccess(call breakerCall) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch {
+	case b.isTrial(call):
+		b.inFlight--
+		b.successes++
+		if b.successes >= b.halfOpenRequests {
+			b.state = breakerClosed
+			b.failures = 0
+		}
+	case !call.trial && b.state == breakerClosed:
+		b.failures = 0
+	}
+}
+
+// onFailure records a failed call. Failures of calls admitted while closed
+// only count while the breaker is still closed.
+func (b *circuitBreaker) onFailure(call breakerCall) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch {
+	case b.isTrial(call):
+		b.open()
+	case !call.trial && b.state == breakerClosed:
+		b.failures++
+		if b.failures >= b.failureThreshold {
+			b.open()
+		}
+	}
+}
+
+// release gives back a call that ended without telling anything about the
+// endpoint, e.g. because the caller went away.
+func (b *circuitBreaker) release(call breakerCall) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.isTrial(call) {
+		b.inFlight--
+	}
+}
+
+func (b *circuitBreaker) open() {
+	b.state = breakerOpen
+	b.openedAt = time.Now()
+	b.inFlight = 0
+	b.successes = 0
+}
+
+func (b *circuitBreaker) currentState() breakerState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.state
+}
@@ -0,0 +1,127 @@
+package rpc
+
+import (
+	"testing"
+	"time"
+)
+
+// openBreaker returns a breaker that opened long enough ago to go half-open
+// on the next acquire.
+func openBreaker(halfOpenRequests int) *circuitBreaker {
+	b := newCircuitBreaker(1, time.Minute, halfOpenRequests)
+	b.open()
+	b.openedAt = time.Now().Add(-time.Hour)
+	return b
+}
+
+func TestCircuitBreakerOpensAfterThreshold(t *testing.T) {
+	b := newCircuitBreaker(3, time.Minute, 1)
+
+	for i := 0; i < 3; i++ {
+		call, ok := b.acquire()
+		if !ok {
+			t.Fatalf("call %d refused while closed", i)
+		}
+		b.onFailure(call)
+	}
+
+	if state := b.currentState(); state != breakerOpen {
+		t.Fatalf("state = %s, want open", state)
+	}
+	if _, ok := b.acquire(); ok {
+		t.Fatal("open breaker admitted a call")
+	}
+}
+
+func TestCircuitBreakerHalfOpen(t *testing.T) {
+	tests := []struct {
+		name             string
+		halfOpenRequests int
+		// outcomes of the admitted trial calls, in order: true for success.
+		outcomes []bool
+		want     breakerState
+	}{
+		{name: "closes after all trials succeed", halfOpenRequests: 2, outcomes: []bool{true, true}, want: breakerClosed},
+		{name: "stays half-open until enough trials succeed", halfOpenRequests: 2, outcomes: []bool{true}, want: breakerHalfOpen},
+		{name: "reopens on a failed trial", halfOpenRequests: 2, outcomes: []bool{true, false}, want: breakerOpen},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := openBreaker(tt.halfOpenRequests)
+
+			calls := make([]breakerCall, 0, len(tt.outcomes))
+			for range tt.outcomes {
+				call, ok := b.acquire()
+				if !ok {
+					t.Fatal("trial call refused")
+				}
+				if !call.trial {
+					t.Fatal("call admitted while half-open is not a trial")
+				}
+				calls = append(calls, call)
+			}
+
+			for i, success := range tt.outcomes {
+				if success {
+					b.onSuccess(calls[i])
+				} else {
+					b.onFailure(calls[i])
+				}
+			}
+
+			if state := b.currentState(); state != tt.want {
+				t.Errorf("state = %s, want %s", state, tt.want)
+			}
+		})
+	}
+}
+
+func TestCircuitBreakerLimitsTrials(t *testing.T) {
+	b := openBreaker(2)
+
+	first, _ := b.acquire()
+	if _, ok := b.acquire(); !ok {
+		t.Fatal("second trial refused")
+	}
+	if _, ok := b.acquire(); ok {
+		t.Fatal("third concurrent trial admitted")
+	}
+
+	// A finished trial frees its slot.
+	b.release(first)
+	if _, ok := b.acquire(); !ok {
+		t.Fatal("trial refused after another was released")
+	}
+}
+
+func TestCircuitBreakerIgnoresCallsFromEarlierStates(t *testing.T) {
+	b := newCircuitBreaker(1, time.Minute, 1)
+
+	// Admitted while closed, finishing after the breaker went half-open.
+	closedCall, _ := b.acquire()
+	failing, _ := b.acquire()
+	b.onFailure(failing)
+	b.openedAt = time.Now().Add(-time.Hour)
+
+	trial, ok := b.acquire()
+	if !ok {
+		t.Fatal("trial refused")
+	}
+
+	b.onSuccess(closedCall)
+	if state := b.currentState(); state != breakerHalfOpen {
+		t.Fatalf("success of a closed call moved the breaker to %s", state)
+	}
+
+	// A trial of an earlier half-open period does not count either.
+	b.onFailure(trial)
+	b.openedAt = time.Now().Add(-time.Hour)
+	if _, ok := b.acquire(); !ok {
+		t.Fatal("trial of the new half-open period refused")
+	}
+	b.onSuccess(trial)
+	if state := b.currentState(); state != breakerHalfOpen {
+		t.Errorf("success of a stale trial moved the breaker to %s", state)
+	}
+}
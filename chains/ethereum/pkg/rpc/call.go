@@ -0,0 +1,333 @@
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/ethclient"
+	gethrpc "github.com/ethereum/go-ethereum/rpc"
+)
+
+// Call runs fn against a healthy HTTP endpoint. When fn fails with a
+// retryable error the failure counts towards the endpoint's circuit
+// breaker and fn is retried on a healthy endpoint it has not run against
+// yet, up to maxRetries attempts. method names the JSON-RPC call fn makes;
+// its cost is taken from the endpoint's rate limit before fn runs. With
+// hedging enabled, fn may also run against a second endpoint at the same
+// time, so it must not have side effects beyond its result.
+func Call[T any](ctx context.Context, c *Client, method string, fn func(context.Context, *ethclient.Client) (T, error)) (T, error) {
+	return call(ctx, c, []string{method}, true, fn)
+}
+
+// Session runs fn against a single healthy HTTP endpoint like Call, for fn
+// making several calls whose answers must come from the same node, such as
+// a block and its receipts. methods names the calls fn may make, and the
+// endpoint must support all of them. Nothing is taken from the endpoint's
+// rate limit up front: fn sends its calls through CallOn and BatchCallOn,
+// which charge what is actually sent. When fn fails with a retryable error,
+// it is run again from the start on another endpoint.
+func Session[T any](ctx context.Context, c *Client, methods []string, fn func(context.Context, *ethclient.Client) (T, error)) (T, error) {
+	return call(ctx, c, methods, false, fn)
+}
+
+// BatchCall sends elems as a single JSON-RPC batch to one endpoint. The
+// batch as a whole is retried like Call; errors of individual elements are
+// left in their Error field for the caller to handle. Batches are never
+// hedged since the elements are shared between attempts.
+func (c *Client) BatchCall(ctx context.Context, elems []gethrpc.BatchElem) error {
+	methods := batchMethods(elems)
+
+	var (
+		lastErr error
+		fn      = func(ctx context.Context, client *ethclient.Client) (struct{}, error) {
+			for i := range elems {
+				elems[i].Error = nil
+			}
+			return struct{}{}, client.Client().BatchCallContext(ctx, elems)
+		}
+	)
+
+	var tried []*HealthyClient
+	for attempt := 0; attempt < maxRetries; attempt++ {
+		hc, err := c.pickClient(untried(c.httpSnapshot(), tried), &c.current, methods, blockTag(ctx))
+		if err != nil {
+			if lastErr != nil {
+				break
+			}
+			return err
+		}
+
+		c.requests.Add(1)
+		_, err = invoke(ctx, c, hc, methods, true, fn)
+		if err == nil || ctx.Err() != nil || !(IsRetryable(err) || IsMethodNotFound(err)) {
+			return err
+		}
+
+		lastErr = err
+		tried = append(tried, hc)
+		c.NextClient()
+	}
+
+	return fmt.Errorf("%s failed on all attempted endpoints: %w", methodList(methods), lastErr)
+}
+
+// CallOn sends a single JSON-RPC call to client, the endpoint a Session is
+// running fn against, after charging it to that endpoint's rate limit.
+func CallOn(ctx context.Context, client *ethclient.Client, result interface{}, method string, args ...interface{}) error {
+	if err := chargeOn(ctx, method); err != nil {
+		return err
+	}
+	return client.Client().CallContext(ctx, result, method, args...)
+}
+
+// BatchCallOn sends elems as a single JSON-RPC batch to client, the
+// endpoint a Call, Session or Quorum is running fn against. Each element is
+// charged to that endpoint's rate limit first, since fn was at most charged
+// for the one method it was started with.
+func BatchCallOn(ctx context.Context, client *ethclient.Client, elems []gethrpc.BatchElem) error {
+	if err := chargeOn(ctx, batchMethods(elems)...); err != nil {
+		return err
+	}
+	return client.Client().BatchCallContext(ctx, elems)
+}
+
+// chargeOn waits until the rate limit of the endpoint fn runs against
+// covers a call to each of methods.
+func chargeOn(ctx context.Context, methods ...string) error {
+	if rl, ok := ctx.Value(limiterKey{}).(*rateLimiter); ok {
+		return rl.wait(ctx, methods...)
+	}
+	return nil
+}
+
+// limiterKey carries the rate limiter of the endpoint fn runs against.
+type limiterKey struct{}
+
+func batchMethods(elems []gethrpc.BatchElem) []string {
+	methods := make([]string, len(elems))
+	for i, elem := range elems {
+		methods[i] = elem.Method
+	}
+	return methods
+}
+
+// call implements Call and Session. With prepay set, the cost of methods is
+// taken from the endpoint's rate limit before fn runs.
+func call[T any](ctx context.Context, c *Client, methods []string, prepay bool, fn func(context.Context, *ethclient.Client) (T, error)) (T, error) {
+	var (
+		zero    T
+		lastErr error
+	)
+
+	var tried []*HealthyClient
+	for attempt := 0; attempt < maxRetries; attempt++ {
+		hc, err := c.pickClient(untried(c.httpSnapshot(), tried), &c.current, methods, blockTag(ctx))
+		if err != nil {
+			if lastErr != nil {
+				break
+			}
+			return zero, err
+		}
+
+		c.requests.Add(1)
+		result, err := hedge(ctx, c, hc, methods, prepay, fn)
+		if err == nil {
+			return result, nil
+		}
+
+		// The caller gave up, or the request itself is bad: another
+		// endpoint won't do any better. An endpoint lacking the method is
+		// skipped from now on, so the retry goes to one that has it.
+		if ctx.Err() != nil || !(IsRetryable(err) || IsMethodNotFound(err)) {
+			return zero, err
+		}
+
+		lastErr = err
+		tried = append(tried, hc)
+		c.NextClient()
+	}
+
+	return zero, fmt.Errorf("%s failed on all attempted endpoints: %w", methodList(methods), lastErr)
+}
+
+// untried returns the clients a retry may go to: those not in tried, or
+// all of them once no untried one is usable. Selectors other than round robin
+// ignore the rotation cursor, so moving it is not enough to avoid sending
+// the retry to the endpoint that just failed.
+func untried(clients, tried []*HealthyClient) []*HealthyClient {
+	if len(tried) == 0 {
+		return clients
+	}
+
+	rest := make([]*HealthyClient, 0, len(clients))
+	usable := false
+	for _, hc := range clients {
+		if !slices.Contains(tried, hc) {
+			rest = append(rest, hc)
+			usable = usable || hc.usable()
+		}
+	}
+
+	if !usable {
+		return clients
+	}
+	return rest
+}
+
+// invoke runs fn once against hc and records the outcome on the endpoint.
+// With prepay set, the cost of methods is taken from the endpoint's rate
+// limit first; otherwise fn charges its own calls.
+func invoke[T any](ctx context.Context, c *Client, hc *HealthyClient, methods []string, prepay bool, fn func(context.Context, *ethclient.Client) (T, error)) (T, error) {
+	var zero T
+
+	// A call waiting on the rate limit is already in flight as far as
+	// draining the endpoint is concerned.
+	hc.inflight.Add(1)
+	defer hc.inflight.Add(-1)
+
+	// The breaker may have opened, or run out of trial calls, since the
+	// endpoint was picked.
+	bc, ok := hc.breaker.acquire()
+	if !ok {
+		return zero, fmt.Errorf("%s: %w", hc.endpoint, errBreakerOpen)
+	}
+
+	if prepay {
+		if err := hc.limiter.wait(ctx, methods...); err != nil {
+			hc.breaker.release(bc)
+			return zero, err
+		}
+	}
+
+	start := time.Now()
+	result, err := fn(context.WithValue(ctx, limiterKey{}, hc.limiter), hc.client.Load())
+
+	switch {
+	case err == nil:
+		hc.recordSuccess(bc, time.Since(start), methods)
+		return result, nil
+	case ctx.Err() != nil:
+		// The caller gave up: the outcome says nothing about the endpoint.
+		hc.breaker.release(bc)
+	case !IsRetryable(err):
+		// The request itself is bad, but the endpoint did answer.
+		hc.breaker.onSuccess(bc)
+		if len(methods) == 1 && IsMethodNotFound(err) {
+			hc.caps.markUnsupported(methods[0])
+		}
+	default:
+		// A single failure does not take the endpoint out of rotation;
+		// the breaker does once failures pile up.
+		hc.recordFailure(bc)
+	}
+
+	return zero, err
+}
+
+type hedgeOutcome[T any] struct {
+	result T
+	err    error
+	hedged bool
+}
+
+// hedge runs fn against primary and, if it has not answered within the
+// endpoint's hedge delay, against a second endpoint as well. The first
+// successful answer wins and the other call is cancelled.
+func hedge[T any](ctx context.Context, c *Client, primary *HealthyClient, methods []string, prepay bool, fn func(context.Context, *ethclient.Client) (T, error)) (T, error) {
+	delay, ok := primary.hedgeDelay(c.hedgePercentile, methods)
+	if !ok {
+		return invoke(ctx, c, primary, methods, prepay, fn)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	outcomes := make(chan hedgeOutcome[T], 2)
+	run := func(hc *HealthyClient, hedged bool) {
+		result, err := invoke(ctx, c, hc, methods, prepay, fn)
+		outcomes <- hedgeOutcome[T]{result: result, err: err, hedged: hedged}
+	}
+
+	go run(primary, false)
+	inFlight := 1
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	for {
+		select {
+		case o := <-outcomes:
+			inFlight--
+			if o.err == nil {
+				if o.hedged {
+					c.hedgesWon.Add(1)
+				}
+				return o.result, nil
+			}
+
+			if inFlight == 0 {
+				return o.result, o.err
+			}
+		case <-timer.C:
+			if hc := c.hedgeClient(primary, methods, blockTag(ctx)); hc != nil {
+				c.hedgesFired.Add(1)
+				inFlight++
+				go run(hc, true)
+			}
+		}
+	}
+}
+
+// hedgeClient returns the endpoint to send a hedged call to, or nil if
+// there is none or the hedging budget is used up.
+func (c *Client) hedgeClient(primary *HealthyClient, methods []string, tag string) *HealthyClient {
+	if float64(c.hedgesFired.Load()) >= float64(c.requests.Load())*float64(c.hedgeMaxPercent)/100 {
+		return nil
+	}
+
+	var best *HealthyClient
+	for _, hc := range c.httpSnapshot() {
+		if hc != primary && hc.usable() && hc.caps.supports(methods, tag) && (best == nil || hc.latencyEWMA() < best.latencyEWMA()) {
+			best = hc
+		}
+	}
+	return best
+}
+
+// markUnhealthy takes an endpoint out of rotation until the next successful
+// health check. The last usable HTTP endpoint is kept, since calls would
+// otherwise have nowhere to go until then.
+func (c *Client) markUnhealthy(hc *HealthyClient, err error) {
+	if c.lastUsableHTTP(hc) {
+		log.Printf("Endpoint %s kept in rotation as the last usable one: %v", hc.endpoint, err)
+		return
+	}
+
+	if hc.isHealthy.CompareAndSwap(true, false) {
+		log.Printf("Endpoint %s marked unhealthy: %v", hc.endpoint, err)
+	}
+}
+
+// lastUsableHTTP reports whether hc is an HTTP endpoint and no other HTTP
+// endpoint is usable.
+func (c *Client) lastUsableHTTP(hc *HealthyClient) bool {
+	clients := c.httpSnapshot()
+	if !slices.Contains(clients, hc) {
+		return false
+	}
+
+	for _, other := range clients {
+		if other != hc && other.usable() {
+			return false
+		}
+	}
+	return true
+}
+
+func methodList(methods []string) string {
+	return strings.Join(slices.Compact(slices.Clone(methods)), ",")
+}
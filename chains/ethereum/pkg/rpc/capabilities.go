@@ -0,0 +1,296 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	gethrpc "github.com/ethereum/go-ethereum/rpc"
+)
+
+// capabilityProbeInterval is how often health checks probe the
+// capabilities of an endpoint again.
+const capabilityProbeInterval = 5 * time.Minute
+
+// stateDepths are the distances behind the head at which the probe looks
+// for historical state, on top of block 1 for a full archive.
+var stateDepths = []uint64{128, 1024, 65536}
+
+// ErrMethodUnsupported is returned when usable endpoints exist but none of
+// them supports the method a call needs.
+var ErrMethodUnsupported = errors.New("no usable endpoint supports the method")
+
+const errCodeMethodNotFound = -32601
+
+// IsMethodNotFound reports whether err says the endpoint does not provide
+// the method that was called.
+func IsMethodNotFound(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	if errors.Is(err, ErrMethodUnsupported) {
+		return true
+	}
+
+	var rpcErr gethrpc.Error
+	if errors.As(err, &rpcErr) && rpcErr.ErrorCode() == errCodeMethodNotFound {
+		return true
+	}
+
+	// Providers disagree on the code, but not much on the wording.
+	msg := strings.ToLower(err.Error())
+	if !strings.Contains(msg, "method") {
+		return false
+	}
+	for _, s := range []string{"not found", "does not exist", "not available", "not supported", "unsupported"} {
+		if strings.Contains(msg, s) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// capabilities is what an endpoint was found to support. Anything not
+// known yet counts as supported, so calls are not held back by a probe that
+// has not run yet or could not reach the endpoint.
+type capabilities struct {
+	mu sync.RWMutex
+
+	probed        time.Time
+	clientVersion string
+
+	// Methods found missing, by probing or because a call failed with
+	// method not found.
+	unsupported map[string]bool
+
+	// debug_ and trace_ namespaces, known only when rpc_modules answered.
+	modules map[string]bool
+
+	// Block tags the endpoint answered the probe for, true if it served
+	// the block. Tags missing from the map are unknown.
+	tags map[string]bool
+
+	// archive is set when state of block 1 is available, stateDepth is the
+	// deepest probed distance behind the head with state available. Only
+	// reported for now, since no call reads historical state yet.
+	archive    bool
+	stateDepth uint64
+}
+
+// Block tags that not every endpoint supports, see WithBlockTag.
+const (
+	BlockTagSafe      = "safe"
+	BlockTagFinalized = "finalized"
+)
+
+type blockTagKey struct{}
+
+// WithBlockTag marks the calls made with ctx as reading the block tagged
+// tag, so they are only routed to endpoints found to support the tag.
+func WithBlockTag(ctx context.Context, tag string) context.Context {
+	return context.WithValue(ctx, blockTagKey{}, tag)
+}
+
+func blockTag(ctx context.Context) string {
+	tag, _ := ctx.Value(blockTagKey{}).(string)
+	return tag
+}
+
+// supports reports whether calls to all of methods, reading the block
+// tagged tag if it is not empty, may be sent to the endpoint.
+func (caps *capabilities) supports(methods []string, tag string) bool {
+	caps.mu.RLock()
+	defer caps.mu.RUnlock()
+
+	if supported, known := caps.tags[tag]; known && !supported {
+		return false
+	}
+
+	for _, method := range methods {
+		if caps.unsupported[method] {
+			return false
+		}
+
+		namespace, _, _ := strings.Cut(method, "_")
+		if (namespace == "debug" || namespace == "trace") && caps.modules != nil && !caps.modules[namespace] {
+			return false
+		}
+	}
+	return true
+}
+
+func (caps *capabilities) markUnsupported(method string) {
+	caps.mu.Lock()
+	defer caps.mu.Unlock()
+
+	if caps.unsupported == nil {
+		caps.unsupported = make(map[string]bool)
+	}
+	caps.unsupported[method] = true
+}
+
+func (caps *capabilities) due() bool {
+	caps.mu.RLock()
+	defer caps.mu.RUnlock()
+	return time.Since(caps.probed) >= capabilityProbeInterval
+}
+
+func (caps *capabilities) status() map[string]interface{} {
+	caps.mu.RLock()
+	defer caps.mu.RUnlock()
+
+	unsupported := make([]string, 0, len(caps.unsupported))
+	for method := range caps.unsupported {
+		unsupported = append(unsupported, method)
+	}
+
+	return map[string]interface{}{
+		"clientVersion": caps.clientVersion,
+		"unsupported":   unsupported,
+		"debug":         caps.modules == nil || caps.modules["debug"],
+		"trace":         caps.modules == nil || caps.modules["trace"],
+		"safeTag":       caps.tagStatus(BlockTagSafe),
+		"finalizedTag":  caps.tagStatus(BlockTagFinalized),
+		"archive":       caps.archive,
+		"stateDepth":    caps.stateDepth,
+	}
+}
+
+// tagStatus reports a block tag on /health: true or false once known,
+// "unknown" before.
+func (caps *capabilities) tagStatus(tag string) interface{} {
+	if supported, known := caps.tags[tag]; known {
+		return supported
+	}
+	return "unknown"
+}
+
+// answered reports whether a probe got an answer from the node, as opposed
+// to failing to reach it.
+func answered(err error) bool {
+	var rpcErr gethrpc.Error
+	return err == nil || errors.As(err, &rpcErr)
+}
+
+// probeCapabilities finds out what hc supports. Findings are only updated
+// by probes the node answered: an endpoint that cannot be reached keeps
+// what was known before and is probed again on its next successful health
+// check.
+func probeCapabilities(ctx context.Context, hc *HealthyClient) {
+	client := hc.client.Load()
+	rpcClient := client.Client()
+	probe := func(result interface{}, method string, args ...interface{}) error {
+		hc.limiter.charge(method)
+		return rpcClient.CallContext(ctx, result, method, args...)
+	}
+
+	var clientVersion string
+	if err := probe(&clientVersion, "web3_clientVersion"); !answered(err) {
+		return
+	}
+
+	// Not every node exposes rpc_modules; debug and trace are then assumed
+	// available and learned from failing calls instead.
+	var modules map[string]string
+	var namespaces map[string]bool
+	modulesErr := probe(&modules, "rpc_modules")
+	if modulesErr == nil {
+		namespaces = make(map[string]bool, len(modules))
+		for name := range modules {
+			namespaces[name] = true
+		}
+	}
+
+	// The genesis block has no transactions, which keeps the probe cheap.
+	var receipts []interface{}
+	receiptsErr := probe(&receipts, "eth_getBlockReceipts", "0x0")
+
+	// A tag is unsupported when the node answers the probe with an error.
+	tags := make(map[string]bool)
+	for _, tag := range []string{BlockTagSafe, BlockTagFinalized} {
+		var head interface{}
+		if err := probe(&head, "eth_getBlockByNumber", tag, false); answered(err) {
+			tags[tag] = err == nil
+		}
+	}
+
+	var (
+		balance    interface{}
+		archive    bool
+		stateDepth uint64
+	)
+	number, numberErr := client.BlockNumber(ctx)
+	if numberErr == nil {
+		archive = probe(&balance, "eth_getBalance", common.Address{}, "0x1") == nil
+		for _, depth := range stateDepths {
+			if depth >= number {
+				break
+			}
+			if probe(&balance, "eth_getBalance", common.Address{}, hexutil.EncodeUint64(number-depth)) != nil {
+				break
+			}
+			stateDepth = depth
+		}
+	}
+
+	hc.caps.mu.Lock()
+	defer hc.caps.mu.Unlock()
+
+	hc.caps.probed = time.Now()
+	hc.caps.clientVersion = clientVersion
+	if answered(modulesErr) {
+		hc.caps.modules = namespaces
+	}
+	if hc.caps.tags == nil {
+		hc.caps.tags = make(map[string]bool)
+	}
+	for tag, supported := range tags {
+		hc.caps.tags[tag] = supported
+	}
+	if numberErr == nil {
+		hc.caps.archive = archive
+		hc.caps.stateDepth = stateDepth
+	}
+
+	switch {
+	case receiptsErr == nil:
+		delete(hc.caps.unsupported, "eth_getBlockReceipts")
+	case IsMethodNotFound(receiptsErr):
+		if hc.caps.unsupported == nil {
+			hc.caps.unsupported = make(map[string]bool)
+		}
+		hc.caps.unsupported["eth_getBlockReceipts"] = true
+	}
+}
+
+// capableClients returns the endpoints that support all of methods and the
+// block tag, if any. It returns ErrMethodUnsupported if some endpoints are
+// usable but none of them supports the call.
+func capableClients(clients []*HealthyClient, methods []string, tag string) ([]*HealthyClient, error) {
+	capable := make([]*HealthyClient, 0, len(clients))
+	usable := false
+	for _, hc := range clients {
+		if !hc.usable() {
+			continue
+		}
+		usable = true
+		if hc.caps.supports(methods, tag) {
+			capable = append(capable, hc)
+		}
+	}
+
+	if usable && len(capable) == 0 {
+		if tag != "" {
+			return nil, fmt.Errorf("%w: %s at %s block", ErrMethodUnsupported, methodList(methods), tag)
+		}
+		return nil, fmt.Errorf("%w: %s", ErrMethodUnsupported, methodList(methods))
+	}
+	return capable, nil
+}
@@ -3,71 +3,263 @@ package rpc
 import (
 	"context"
 	"fmt"
+	"log"
+	"maps"
+	"math"
 	"math/big"
+	"slices"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
 
+	"github.com/al002/sylph/chains/ethereum/pkg/config"
 	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/node"
+	gethrpc "github.com/ethereum/go-ethereum/rpc"
 )
 
 const (
 	healthCheckInterval = 30 * time.Second
 	dialTimeout         = 5 * time.Second
 	maxRetries          = 3
+
+	// Number of recent call latencies kept per endpoint for hedging, and
+	// how many are needed before hedge delays are trusted.
+	latencySamples    = 100
+	minLatencySamples = 20
 )
 
 type HealthyClient struct {
-	client       *ethclient.Client
-	endpoint     string
+	// client is nil until the endpoint has been dialed. A WS endpoint that
+	// cannot be dialed at startup is dialed again by the health checks.
+	client       atomic.Pointer[ethclient.Client]
+	ep           config.Endpoint
+	url          string // as configured, used to look the endpoint up
+	endpoint     string // url with secrets redacted, safe to log
+	priority     int
 	isHealthy    atomic.Bool
 	lastCheck    atomic.Int64 // Unix timestamp
 	latency      atomic.Int64 // in milliseconds
 	failureCount atomic.Int32
 	successCount atomic.Int32
+
+	// Outcomes of the calls routed to this endpoint.
+	requests        atomic.Int64
+	requestFailures atomic.Int64
+	latencyBits     atomic.Uint64 // EWMA in milliseconds, as float64 bits
+
+	// Recent call latencies, one ring per set of methods so cheap and heavy
+	// calls get their own hedge delay. Keyed by sampleKey.
+	samplesMu sync.Mutex
+	samples   map[string]*latencyRing
+
+	breaker *circuitBreaker
+	limiter *rateLimiter
+
+	// Number of quorum reads this endpoint disagreed with.
+	dissents atomic.Int64
+
+	// Head block seen by the last health check, 0 if it failed.
+	headNumber atomic.Uint64
+	lagging    atomic.Bool
+
+	// Chain ID seen by the last successful health check. Endpoints on
+	// another chain than the client are never used.
+	chainID       atomic.Uint64
+	chainMismatch atomic.Bool
+
+	// What the endpoint supports, see probeCapabilities.
+	caps capabilities
+
+	// Set through the admin API. Disabled and draining endpoints get no new
+	// calls; inflight counts the calls still running against the endpoint.
+	disabled atomic.Bool
+	draining atomic.Bool
+	inflight atomic.Int64
+}
+
+// usable reports whether calls may be routed to the endpoint.
+func (hc *HealthyClient) usable() bool {
+	return hc.isHealthy.Load() && !hc.lagging.Load() && !hc.chainMismatch.Load() &&
+		!hc.disabled.Load() && !hc.draining.Load() && hc.breaker.available()
+}
+
+func (hc *HealthyClient) latencyEWMA() float64 {
+	return math.Float64frombits(hc.latencyBits.Load())
+}
+
+func (hc *HealthyClient) observeLatency(d time.Duration) {
+	sample := float64(d.Microseconds()) / 1000
+	for {
+		old := hc.latencyBits.Load()
+		ewma := sample
+		if old != 0 {
+			ewma = latencyAlpha*sample + (1-latencyAlpha)*math.Float64frombits(old)
+		}
+		if hc.latencyBits.CompareAndSwap(old, math.Float64bits(ewma)) {
+			return
+		}
+	}
+}
+
+// successRate is the share of successful calls, smoothed so endpoints
+// without traffic start at 0.5 instead of 0 or 1.
+func (hc *HealthyClient) successRate() float64 {
+	requests := float64(hc.requests.Load())
+	failures := float64(hc.requestFailures.Load())
+	return (requests - failures + 1) / (requests + 2)
+}
+
+func (hc *HealthyClient) recordSuccess(call breakerCall, d time.Duration, methods []string) {
+	hc.requests.Add(1)
+	hc.observeLatency(d)
+	hc.addSample(d, methods)
+	hc.breaker.onSuccess(call)
+}
+
+// latencyRing holds the most recent latencySamples latencies of a call.
+type latencyRing struct {
+	samples []time.Duration
+	idx     int
+}
+
+// sampleKey identifies the latency ring of a call to methods. Unlike
+// methodList it keeps repeated methods, so a batch does not share a ring
+// with a single call of the same method.
+func sampleKey(methods []string) string {
+	return strings.Join(methods, ",")
+}
+
+func (hc *HealthyClient) addSample(d time.Duration, methods []string) {
+	hc.samplesMu.Lock()
+	defer hc.samplesMu.Unlock()
+
+	key := sampleKey(methods)
+	ring, ok := hc.samples[key]
+	if !ok {
+		if hc.samples == nil {
+			hc.samples = make(map[string]*latencyRing)
+		}
+		ring = &latencyRing{}
+		hc.samples[key] = ring
+	}
+
+	if len(ring.samples) < latencySamples {
+		ring.samples = append(ring.samples, d)
+		return
+	}
+	ring.samples[ring.idx] = d
+	ring.idx = (ring.idx + 1) % latencySamples
+}
+
+// hedgeDelay returns the given percentile of the endpoint's recent
+// latencies for calls to methods. It returns false if percentile is 0 or
+// there are not enough samples yet.
+func (hc *HealthyClient) hedgeDelay(percentile int, methods []string) (time.Duration, bool) {
+	if percentile <= 0 {
+		return 0, false
+	}
+
+	var samples []time.Duration
+	hc.samplesMu.Lock()
+	if ring, ok := hc.samples[sampleKey(methods)]; ok {
+		samples = slices.Clone(ring.samples)
+	}
+	hc.samplesMu.Unlock()
+
+	if len(samples) < minLatencySamples {
+		return 0, false
+	}
+
+	slices.Sort(samples)
+	idx := min(len(samples)*percentile/100, len(samples)-1)
+	return samples[idx], true
+}
+
+func (hc *HealthyClient) recordFailure(call breakerCall) {
+	hc.requests.Add(1)
+	hc.requestFailures.Add(1)
+	hc.breaker.onFailure(call)
 }
 
 type Client struct {
-	httpEndpoints []string
-	httpClients   []*HealthyClient
-	current       atomic.Int32
+	cfg *config.Config
+
+	// The endpoint lists are copy-on-write: they are replaced under mu, never
+	// modified in place, so a snapshot taken under mu stays valid.
+	httpClients []*HealthyClient
+	current     atomic.Int32
+
+	wsClients []*HealthyClient
+	wsCurrent atomic.Int32
+
+	selector Selector
 
-	wsEndpoints []string
-	wsClients   []*HealthyClient
-	wsCurrent   atomic.Int32
+	// chainID is the chain all endpoints must serve, 0 until it is known.
+	chainID atomic.Uint64
+
+	// Endpoints more than maxHeadLag blocks behind bestHead are not used.
+	maxHeadLag int
+	bestHead   atomic.Uint64
+
+	// Calls are hedged after the hedgePercentile latency of the endpoint,
+	// for at most hedgeMaxPercent of all calls. 0 disables hedging.
+	hedgePercentile int
+	hedgeMaxPercent int
+	requests        atomic.Int64
+	hedgesFired     atomic.Int64
+	hedgesWon       atomic.Int64
+
+	// headPollInterval is how often heads are polled without a usable WS
+	// endpoint.
+	headPollInterval time.Duration
 
 	mu     sync.RWMutex
 	closed atomic.Bool
 }
 
-func NewClient(httpEndpoints, wsEndpoints []string) (*Client, error) {
+func NewClient(cfg *config.Config) (*Client, error) {
+	selector, err := newSelector(cfg.SelectionStrategy)
+	if err != nil {
+		return nil, err
+	}
+
 	c := &Client{
-		httpEndpoints: httpEndpoints,
-		wsEndpoints:   wsEndpoints,
+		cfg:        cfg,
+		selector:   selector,
+		maxHeadLag: cfg.MaxHeadLag,
+
+		hedgePercentile: cfg.HedgePercentile,
+		hedgeMaxPercent: cfg.HedgeMaxPercent,
+
+		headPollInterval: time.Duration(cfg.HeadPollInterval) * time.Second,
 	}
+	c.chainID.Store(uint64(cfg.ExpectedChainID))
 
-	const dialTimeout = 5 * time.Second
-	for _, endpoint := range httpEndpoints {
-		client, err := dialWithTimeout(endpoint)
+	for _, raw := range cfg.HTTPEndpoints {
+		hc, err := newHealthyClient(raw, cfg)
+		if err == nil {
+			err = hc.dial()
+		}
 		if err != nil {
-			return nil, fmt.Errorf("failed to dial HTTP endpoint %s: %v", endpoint, err)
+			return nil, fmt.Errorf("failed to dial HTTP endpoint %v", err)
 		}
-		c.httpClients = append(c.httpClients, &HealthyClient{
-			client:   client,
-			endpoint: endpoint,
-		})
+		c.httpClients = append(c.httpClients, hc)
 	}
 
-	for _, endpoint := range wsEndpoints {
-		client, err := dialWithTimeout(endpoint)
+	// WS endpoints connect when dialed. One that is down at startup stays
+	// unhealthy until a health check manages to dial it, and heads are
+	// polled over HTTP meanwhile.
+	for _, raw := range cfg.WSEndpoints {
+		hc, err := newHealthyClient(raw, cfg)
 		if err != nil {
-			return nil, fmt.Errorf("failed to dial WS endpoint %s: %v", endpoint, err)
+			return nil, fmt.Errorf("invalid WS endpoint: %v", err)
 		}
-
-		c.wsClients = append(c.wsClients, &HealthyClient{
-			client:   client,
-			endpoint: endpoint,
-		})
+		if err := hc.dial(); err != nil {
+			log.Printf("Failed to dial WS endpoint %v, retrying on health checks", err)
+		}
+		c.wsClients = append(c.wsClients, hc)
 	}
 
 	if len(c.httpClients) == 0 {
@@ -79,13 +271,92 @@ func NewClient(httpEndpoints, wsEndpoints []string) (*Client, error) {
 	return c, nil
 }
 
-func dialWithTimeout(endpoint string) (*ethclient.Client, error) {
+// newHealthyClient sets up the endpoint raw without connecting to it; see
+// dial.
+func newHealthyClient(raw string, cfg *config.Config) (*HealthyClient, error) {
+	ep, err := config.ParseEndpoint(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	// Costs set on the endpoint win over those of the config.
+	costs, err := config.ParseMethodCosts(cfg.MethodCosts)
+	if err != nil {
+		return nil, err
+	}
+	maps.Copy(costs, ep.MethodCosts)
+
+	return &HealthyClient{
+		ep:       ep,
+		url:      ep.URL,
+		endpoint: ep.Redacted(),
+		priority: ep.Priority,
+		breaker: newCircuitBreaker(
+			cfg.BreakerFailureThreshold,
+			time.Duration(cfg.BreakerOpenTimeout)*time.Second,
+			cfg.BreakerHalfOpenRequests,
+		),
+		limiter: newRateLimiter(ep.RateLimit, ep.Burst, costs),
+	}, nil
+}
+
+// dial connects to the endpoint and probes what it supports. When two
+// health checks dial at once, the connection of the slower one is dropped.
+func (hc *HealthyClient) dial() error {
+	client, err := dialWithTimeout(hc.ep)
+	if err != nil {
+		return fmt.Errorf("%s: %v", hc.endpoint, err)
+	}
+	if !hc.client.CompareAndSwap(nil, client) {
+		client.Close()
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), dialTimeout)
+	defer cancel()
+	probeCapabilities(ctx, hc)
+
+	return nil
+}
+
+// close closes the connection to the endpoint, if it was ever made.
+func (hc *HealthyClient) close() {
+	if client := hc.client.Load(); client != nil {
+		client.Close()
+	}
+}
+
+func dialWithTimeout(ep config.Endpoint) (*ethclient.Client, error) {
+	var opts []gethrpc.ClientOption
+	if len(ep.Headers) > 0 {
+		opts = append(opts, gethrpc.WithHeaders(ep.Headers))
+	}
+
+	// Basic auth credentials in the URL are sent by the transports
+	// themselves.
+	if ep.JWTSecretFile != "" {
+		secret, err := config.ReadJWTSecret(ep.JWTSecretFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read JWT secret: %w", err)
+		}
+		opts = append(opts, gethrpc.WithHTTPAuth(node.NewJWTAuth(secret)))
+	}
+
 	ctx, cancel := context.WithTimeout(context.Background(), dialTimeout)
 	defer cancel()
-	return ethclient.DialContext(ctx, endpoint)
+
+	client, err := gethrpc.DialOptions(ctx, ep.URL, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return ethclient.NewClient(client), nil
 }
 
 func (c *Client) startHealthChecks() {
+	// HTTP endpoints are checked by initializeClients on first use, WS
+	// endpoints need a check before they can be subscribed to.
+	c.checkClientsHealth(c.wsSnapshot())
+
 	ticker := time.NewTicker(healthCheckInterval)
 	defer ticker.Stop()
 
@@ -94,11 +365,25 @@ func (c *Client) startHealthChecks() {
 			return
 		}
 
-		c.checkClientsHealth(c.httpClients)
-		c.checkClientsHealth(c.wsClients)
+		c.checkClientsHealth(c.httpSnapshot())
+		c.checkClientsHealth(c.wsSnapshot())
 	}
 }
 
+// httpSnapshot returns the current HTTP endpoints.
+func (c *Client) httpSnapshot() []*HealthyClient {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.httpClients
+}
+
+// wsSnapshot returns the current WS endpoints.
+func (c *Client) wsSnapshot() []*HealthyClient {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.wsClients
+}
+
 func (c *Client) checkClientsHealth(clients []*HealthyClient) {
 	var wg sync.WaitGroup
 	for _, hc := range clients {
@@ -107,20 +392,46 @@ func (c *Client) checkClientsHealth(clients []*HealthyClient) {
 			defer wg.Done()
 
 			if failures := hc.failureCount.Load(); failures > 0 {
-				backoff := time.Duration(1<<uint(failures)) * time.Second
+				backoff := time.Duration(1<<uint(min(failures, 5))) * time.Second
 				if backoff > 30*time.Second {
 					backoff = 30 * time.Second
 				}
 				time.Sleep(backoff)
 			}
 
+			if hc.client.Load() == nil {
+				if err := hc.dial(); err != nil {
+					hc.isHealthy.Store(false)
+					hc.failureCount.Add(1)
+					hc.lastCheck.Store(time.Now().Unix())
+					return
+				}
+				log.Printf("Endpoint %s dialed", hc.endpoint)
+			}
+			client := hc.client.Load()
+
 			ctx, cancel := context.WithTimeout(context.Background(), dialTimeout)
 			defer cancel()
 
+			hc.limiter.charge("eth_chainId")
 			start := time.Now()
-			_, err := hc.client.ChainID(ctx)
+			chainID, err := client.ChainID(ctx)
 			latency := time.Since(start).Milliseconds()
 
+			if err == nil {
+				// Checked before the endpoint is marked healthy, so it is
+				// never used while on the wrong chain.
+				hc.chainID.Store(chainID.Uint64())
+				c.checkChainID(hc)
+			}
+
+			var head uint64
+			if err == nil {
+				hc.limiter.charge("eth_blockNumber")
+				head, err = client.BlockNumber(ctx)
+			}
+			hc.headNumber.Store(head)
+
 			if err == nil {
 				hc.isHealthy.Store(true)
 				hc.successCount.Add(1)
@@ -132,13 +443,118 @@ func (c *Client) checkClientsHealth(clients []*HealthyClient) {
 
 			hc.lastCheck.Store(time.Now().Unix())
 			hc.latency.Store(latency)
+			if err == nil {
+				hc.observeLatency(time.Duration(latency) * time.Millisecond)
+			}
+
+			if err == nil && hc.caps.due() {
+				probeCtx, probeCancel := context.WithTimeout(context.Background(), dialTimeout)
+				probeCapabilities(probeCtx, hc)
+				probeCancel()
+			}
 		}(hc)
 	}
 	wg.Wait()
+
+	if err := c.pinChainID(clients); err != nil {
+		log.Printf("Chain ID check failed: %v", err)
+	}
+	for _, hc := range clients {
+		c.checkChainID(hc)
+	}
+	c.updateHeadLag()
+}
+
+// pinChainID takes the client's chain ID from clients if it is not known
+// yet. It fails if the endpoints disagree, since there is no telling which
+// of them is right.
+func (c *Client) pinChainID(clients []*HealthyClient) error {
+	if c.chainID.Load() != 0 {
+		return nil
+	}
+
+	seen := make(map[uint64][]string)
+	for _, hc := range clients {
+		if id := hc.chainID.Load(); id != 0 {
+			seen[id] = append(seen[id], hc.endpoint)
+		}
+	}
+
+	if len(seen) > 1 {
+		return fmt.Errorf("endpoints disagree on chain ID: %v", seen)
+	}
+
+	for id := range seen {
+		if c.chainID.CompareAndSwap(0, id) {
+			log.Printf("Using chain ID %d", id)
+		}
+	}
+	return nil
+}
+
+// checkChainID takes hc out of rotation while it serves another chain than
+// the client.
+func (c *Client) checkChainID(hc *HealthyClient) {
+	want, got := c.chainID.Load(), hc.chainID.Load()
+	if want == 0 || got == 0 {
+		return
+	}
+
+	mismatch := got != want
+	if hc.chainMismatch.Swap(mismatch) != mismatch {
+		if mismatch {
+			log.Printf("Endpoint %s serves chain ID %d instead of %d, removed from rotation", hc.endpoint, got, want)
+		} else {
+			log.Printf("Endpoint %s serves chain ID %d again", hc.endpoint, want)
+		}
+	}
+}
+
+// updateHeadLag demotes endpoints whose head is more than maxHeadLag blocks
+// behind the best head seen across all endpoints, and restores those that
+// caught up.
+func (c *Client) updateHeadLag() {
+	clients := append(slices.Clone(c.httpSnapshot()), c.wsSnapshot()...)
+
+	var best uint64
+	for _, hc := range clients {
+		// The head of another chain says nothing about this one.
+		if !hc.chainMismatch.Load() {
+			best = max(best, hc.headNumber.Load())
+		}
+	}
+	c.bestHead.Store(best)
+
+	if c.maxHeadLag <= 0 {
+		return
+	}
+
+	for _, hc := range clients {
+		head := hc.headNumber.Load()
+		// Unreachable endpoints are already out of rotation.
+		if head == 0 {
+			continue
+		}
+
+		lagging := best-head > uint64(c.maxHeadLag)
+		if hc.lagging.Swap(lagging) != lagging {
+			if lagging {
+				log.Printf("Endpoint %s is %d blocks behind head %d, removed from rotation", hc.endpoint, best-head, best)
+			} else {
+				log.Printf("Endpoint %s caught up with head %d", hc.endpoint, best)
+			}
+		}
+	}
+}
+
+// BestHead returns the highest head seen by the last health check across
+// all endpoints on the right chain.
+func (c *Client) BestHead() uint64 {
+	return c.bestHead.Load()
 }
 
 func (c *Client) CurrentClient() (*ethclient.Client, error) {
-	return c.getHealthyClient(c.httpClients, &c.current)
+	return c.getHealthyClient(c.httpSnapshot(), &c.current)
 }
 
 func (c *Client) NextClient() {
@@ -148,77 +564,81 @@ func (c *Client) NextClient() {
 func (c *Client) Close() {
 	c.closed.Store(true)
 
-	for _, hc := range append(c.httpClients, c.wsClients...) {
-		if hc.client != nil {
-			hc.client.Close()
-		}
+	for _, hc := range append(slices.Clone(c.httpSnapshot()), c.wsSnapshot()...) {
+		hc.close()
 	}
 }
 
 func (c *Client) WSClient() (*ethclient.Client, error) {
-	return c.getHealthyClient(c.wsClients, &c.wsCurrent)
+	return c.getHealthyClient(c.wsSnapshot(), &c.wsCurrent)
 }
 
 func (c *Client) getHealthyClient(clients []*HealthyClient, current *atomic.Int32) (*ethclient.Client, error) {
-	candidates := make([]int, 0, len(clients))
-	for i, hc := range clients {
-		if hc.isHealthy.Load() {
-			candidates = append(candidates, i)
-		}
+	hc, err := c.pickClient(clients, current, nil, "")
+	if err != nil {
+		return nil, err
 	}
 
-	if len(candidates) == 0 {
-		return nil, fmt.Errorf("no healthy clients available")
-	}
+	return hc.client.Load(), nil
+}
 
-	cur := int(current.Add(0))
+// pickClient returns the client the selection strategy picks from the
+// clients that support all of methods and the block tag, if any.
+func (c *Client) pickClient(clients []*HealthyClient, current *atomic.Int32, methods []string, tag string) (*HealthyClient, error) {
+	clients, err := capableClients(clients, methods, tag)
+	if err != nil {
+		return nil, err
+	}
 
-	for offset := 0; offset < len(candidates); offset++ {
-		idx := (cur + offset) % len(clients)
-		if hc := clients[idx]; hc.isHealthy.Load() {
-			current.Store(int32(idx))
-			return hc.client, nil
-		}
+	hc := c.selector.Select(clients, current)
+	if hc == nil {
+		return nil, fmt.Errorf("no healthy clients available")
 	}
 
-	return clients[candidates[0]].client, nil
+	return hc, nil
 }
 
 func (c *Client) NextWSClient() {
 	c.wsCurrent.Add(1)
 }
 
+// ChainID returns the chain ID every endpoint is checked against. Without
+// a configured chain ID it is taken from the endpoints, and ChainID fails if
+// they disagree.
 func (c *Client) ChainID() (*big.Int, error) {
 	if err := c.initializeClients(); err != nil {
 		return nil, fmt.Errorf("failed to initialize clients: %w", err)
 	}
 
-	var lastErr error
+	if err := c.pinChainID(append(slices.Clone(c.httpSnapshot()), c.wsSnapshot()...)); err != nil {
+		return nil, err
+	}
 
-	for i := 0; i < len(c.httpClients); i++ {
-		client := c.httpClients[i].client
-		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-		defer cancel()
+	id := c.chainID.Load()
+	if id == 0 {
+		return nil, fmt.Errorf("failed to get chain ID from any client")
+	}
 
-		chainID, err := client.ChainID(ctx)
-		if err == nil {
-			return chainID, nil
+	for _, hc := range c.httpSnapshot() {
+		if hc.usable() {
+			return new(big.Int).SetUint64(id), nil
 		}
-		lastErr = err
 	}
 
-	return nil, fmt.Errorf("failed to get chain ID from any client: %w", lastErr)
-
+	return nil, fmt.Errorf("no healthy HTTP endpoint serves chain ID %d", id)
 }
 
 func (c *Client) initializeClients() error {
-	if len(c.httpClients) > 0 && c.httpClients[0].isHealthy.Load() {
+	clients := c.httpSnapshot()
+	if len(clients) > 0 && clients[0].isHealthy.Load() {
 		return nil
 	}
 
-	c.checkClientsHealth(c.httpClients)
+	// WS endpoints are checked along with the HTTP ones so their chain ID
+	// is compared before any of them is used.
+	c.checkClientsHealth(append(slices.Clone(clients), c.wsSnapshot()...))
 
-	for _, client := range c.httpClients {
+	for _, client := range clients {
 		if client.isHealthy.Load() {
 			return nil
 		}
@@ -227,6 +647,31 @@ func (c *Client) initializeClients() error {
 	return fmt.Errorf("no healthy clients available after initialization")
 }
 
+func (hc *HealthyClient) status() map[string]interface{} {
+	return map[string]interface{}{
+		"endpoint":    hc.endpoint,
+		"healthy":     hc.isHealthy.Load(),
+		"lastCheck":   time.Unix(hc.lastCheck.Load(), 0),
+		"latency":     hc.latency.Load(),
+		"latencyEwma": hc.latencyEWMA(),
+		"priority":    hc.priority,
+		"requests":    hc.requests.Load(),
+		"failures":    hc.requestFailures.Load(),
+		"breaker":     hc.breaker.currentState().String(),
+		"head":        hc.headNumber.Load(),
+		"lagging":     hc.lagging.Load(),
+		"chainId":     hc.chainID.Load(),
+		"wrongChain":  hc.chainMismatch.Load(),
+		"dissents":    hc.dissents.Load(),
+		"disabled":    hc.disabled.Load(),
+		"draining":    hc.draining.Load(),
+		"inflight":    hc.inflight.Load(),
+		"usage":       hc.limiter.status(),
+
+		"capabilities": hc.caps.status(),
+	}
+}
+
 func (c *Client) HealthStatus() map[string]interface{} {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
@@ -235,25 +680,24 @@ func (c *Client) HealthStatus() map[string]interface{} {
 
 	httpStatus := make([]map[string]interface{}, len(c.httpClients))
 	for i, hc := range c.httpClients {
-		httpStatus[i] = map[string]interface{}{
-			"endpoint":  hc.endpoint,
-			"healthy":   hc.isHealthy.Load(),
-			"lastCheck": time.Unix(hc.lastCheck.Load(), 0),
-			"latency":   hc.latency.Load(),
-		}
+		httpStatus[i] = hc.status()
 	}
 
 	wsStatus := make([]map[string]interface{}, len(c.wsClients))
 	for i, hc := range c.wsClients {
-		wsStatus[i] = map[string]interface{}{
-			"endpoint":  hc.endpoint,
-			"healthy":   hc.isHealthy.Load(),
-			"lastCheck": time.Unix(hc.lastCheck.Load(), 0),
-			"latency":   hc.latency.Load(),
-		}
+		wsStatus[i] = hc.status()
 	}
 
 	status["http"] = httpStatus
 	status["ws"] = wsStatus
+	status["bestHead"] = c.bestHead.Load()
+	status["chainId"] = c.chainID.Load()
+	status["hedging"] = map[string]interface{}{
+		"percentile": c.hedgePercentile,
+		"maxPercent": c.hedgeMaxPercent,
+		"requests":   c.requests.Load(),
+		"fired":      c.hedgesFired.Load(),
+		"won":        c.hedgesWon.Load(),
+	}
 	return status
 }
@@ -0,0 +1,79 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"syscall"
+
+	gethrpc "github.com/ethereum/go-ethereum/rpc"
+)
+
+// ErrBadResponse is wrapped by callers whose check of an answer failed, such
+// as a block whose contents do not match its header. Such errors are
+// retryable: another endpoint may well answer correctly.
+var ErrBadResponse = errors.New("bad response from endpoint")
+
+// errBreakerOpen is returned for calls the circuit breaker of the picked
+// endpoint refused. Another endpoint may take them.
+var errBreakerOpen = errors.New("circuit breaker refused call")
+
+// JSON-RPC error codes providers use for throttling and transient failures.
+const (
+	errCodeLimitExceeded = -32005
+	errCodeInternal      = -32603
+)
+
+// IsRetryable reports whether a failed call may succeed when it is retried,
+// typically against another endpoint. Timeouts, throttling, server errors and
+// broken connections are retryable; errors describing the request itself,
+// such as invalid params or an unknown block, are not.
+func IsRetryable(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) {
+		return false
+	}
+
+	if errors.Is(err, context.DeadlineExceeded) ||
+		errors.Is(err, io.EOF) ||
+		errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, syscall.ECONNREFUSED) ||
+		errors.Is(err, syscall.EPIPE) ||
+		errors.Is(err, gethrpc.ErrClientQuit) ||
+		errors.Is(err, ErrBadResponse) ||
+		errors.Is(err, errBreakerOpen) {
+		return true
+	}
+
+	var httpErr gethrpc.HTTPError
+	if errors.As(err, &httpErr) {
+		return httpErr.StatusCode == http.StatusTooManyRequests || httpErr.StatusCode >= http.StatusInternalServerError
+	}
+
+	var rpcErr gethrpc.Error
+	if errors.As(err, &rpcErr) {
+		switch rpcErr.ErrorCode() {
+		case errCodeLimitExceeded, errCodeInternal:
+			return true
+		}
+		return false
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return true
+	}
+
+	// Websocket and proxy errors often only carry a message.
+	msg := strings.ToLower(err.Error())
+	for _, s := range []string{"connection reset", "broken pipe", "too many requests", "rate limit"} {
+		if strings.Contains(msg, s) {
+			return true
+		}
+	}
+
+	return false
+}
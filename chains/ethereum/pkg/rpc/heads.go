@@ -0,0 +1,219 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"log"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+const (
+	// Delay before resubscribing after a failed subscription, doubled on
+	// every consecutive failure.
+	minResubscribeBackoff = time.Second
+	maxResubscribeBackoff = 30 * time.Second
+
+	// headStallTimeout is how long a subscription may go without a head
+	// before it is considered dead and replaced.
+	headStallTimeout = 2 * time.Minute
+)
+
+var errHeadStall = errors.New("no new head received")
+
+// HeadTracker follows the head of the chain.
+type HeadTracker interface {
+	// Track sends new heads to out until ctx is done or the tracker can no
+	// longer follow the chain. Heads may skip blocks.
+	Track(ctx context.Context, out chan<- *types.Header) error
+}
+
+// SubscribeHeads follows new heads until ctx is done, then closes the
+// returned channel. Heads come from a WS subscription when a WS endpoint is
+// usable and from polling the HTTP endpoints otherwise. When the
+// subscription fails or stalls it is replaced by one on the next WS
+// endpoint, with backoff, and the current head is fetched over HTTP so
+// heads missed during the outage are not silently skipped. Consumers must
+// be prepared for gaps between heads.
+func (c *Client) SubscribeHeads(ctx context.Context) <-chan *types.Header {
+	out := make(chan *types.Header)
+	go c.superviseHeads(ctx, out)
+	return out
+}
+
+func (c *Client) superviseHeads(ctx context.Context, out chan<- *types.Header) {
+	defer close(out)
+
+	backoff := minResubscribeBackoff
+	reconnect := false
+	for {
+		if !c.wsAvailable() {
+			log.Printf("No usable WS endpoint, polling for new heads every %v", c.headPollInterval)
+			tracker := &pollingHeadTracker{c: c, interval: c.headPollInterval, until: c.wsAvailable}
+			if tracker.Track(ctx, out) != nil {
+				return
+			}
+
+			reconnect = true
+			continue
+		}
+
+		err := (&wsHeadTracker{c: c, reconnect: reconnect}).Track(ctx, out)
+		if ctx.Err() != nil {
+			return
+		}
+
+		log.Printf("Head subscription failed, resubscribing in %v: %v", backoff, err)
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			return
+		}
+
+		backoff = min(backoff*2, maxResubscribeBackoff)
+		if errors.Is(err, errHeadStall) {
+			backoff = minResubscribeBackoff
+		}
+		reconnect = true
+	}
+}
+
+// wsAvailable reports whether any WS endpoint is usable.
+func (c *Client) wsAvailable() bool {
+	for _, hc := range c.wsSnapshot() {
+		if hc.usable() {
+			return true
+		}
+	}
+	return false
+}
+
+// latestHeader fetches the current head over HTTP.
+func (c *Client) latestHeader(ctx context.Context) (*types.Header, error) {
+	return Call(ctx, c, "eth_getBlockByNumber", func(ctx context.Context, client *ethclient.Client) (*types.Header, error) {
+		return client.HeaderByNumber(ctx, nil)
+	})
+}
+
+// wsHeadTracker follows heads with a single subscription on a healthy WS
+// endpoint. Track returns when the subscription fails.
+type wsHeadTracker struct {
+	c *Client
+
+	// reconnect delivers the current head as soon as the subscription is
+	// up, to fill an outage without waiting for the next block.
+	reconnect bool
+}
+
+func (t *wsHeadTracker) Track(ctx context.Context, out chan<- *types.Header) error {
+	c := t.c
+	hc, err := c.pickClient(c.wsSnapshot(), &c.wsCurrent, []string{"eth_subscribe"}, "")
+	if err != nil {
+		return err
+	}
+
+	headers := make(chan *types.Header)
+	sub, err := hc.client.Load().SubscribeNewHead(ctx, headers)
+	if err != nil {
+		c.markUnhealthy(hc, err)
+		c.NextWSClient()
+		return err
+	}
+	defer sub.Unsubscribe()
+
+	if t.reconnect {
+		log.Printf("Head subscription resumed on %s", hc.endpoint)
+
+		if head, err := c.latestHeader(ctx); err == nil {
+			select {
+			case out <- head:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+	}
+
+	stall := time.NewTimer(headStallTimeout)
+	defer stall.Stop()
+
+	for {
+		select {
+		case err := <-sub.Err():
+			if err == nil {
+				err = errors.New("subscription closed")
+			}
+			c.markUnhealthy(hc, err)
+			c.NextWSClient()
+			return err
+		case <-stall.C:
+			c.NextWSClient()
+			return errHeadStall
+		case header := <-headers:
+			select {
+			case out <- header:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+
+			// Reset only once the head is taken, so a slow consumer is not
+			// mistaken for a stalled subscription.
+			stall.Reset(headStallTimeout)
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// pollingHeadTracker follows heads by polling eth_blockNumber on the HTTP
+// endpoints and fetching the header whenever the head moves. Track returns
+// nil as soon as until reports true.
+type pollingHeadTracker struct {
+	c        *Client
+	interval time.Duration
+	until    func() bool
+}
+
+func (t *pollingHeadTracker) Track(ctx context.Context, out chan<- *types.Header) error {
+	ticker := time.NewTicker(t.interval)
+	defer ticker.Stop()
+
+	var last uint64
+	for {
+		if t.until != nil && t.until() {
+			return nil
+		}
+
+		number, err := Call(ctx, t.c, "eth_blockNumber", func(ctx context.Context, client *ethclient.Client) (uint64, error) {
+			return client.BlockNumber(ctx)
+		})
+
+		if err == nil && number > last {
+			var head *types.Header
+			head, err = Call(ctx, t.c, "eth_getBlockByNumber", func(ctx context.Context, client *ethclient.Client) (*types.Header, error) {
+				return client.HeaderByNumber(ctx, new(big.Int).SetUint64(number))
+			})
+
+			if err == nil {
+				select {
+				case out <- head:
+					last = number
+				case <-ctx.Done():
+					return ctx.Err()
+				}
+			}
+		}
+
+		if err != nil && ctx.Err() == nil {
+			log.Printf("Failed to poll for new heads: %v", err)
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
@@ -0,0 +1,118 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+// ErrNoQuorum is returned when fewer endpoints than required agree on an
+// answer.
+var ErrNoQuorum = errors.New("no quorum")
+
+type quorumAnswer[T any] struct {
+	hc     *HealthyClient
+	result T
+	err    error
+}
+
+// Quorum runs fn against n distinct usable HTTP endpoints at once and
+// returns the answer that at least k of them agree on, comparing answers by
+// key. Endpoints that answered differently from the quorum are counted as
+// dissenting and taken out of rotation.
+func Quorum[T any](ctx context.Context, c *Client, method string, n, k int, fn func(context.Context, *ethclient.Client) (T, error), key func(T) string) (T, error) {
+	var zero T
+
+	clients := c.quorumClients(n, method, blockTag(ctx))
+	if len(clients) < k {
+		return zero, fmt.Errorf("%w for %s: %d usable endpoints, %d required", ErrNoQuorum, method, len(clients), k)
+	}
+
+	answers := make(chan quorumAnswer[T], len(clients))
+	for _, hc := range clients {
+		go func(hc *HealthyClient) {
+			c.requests.Add(1)
+			result, err := invoke(ctx, c, hc, []string{method}, true, fn)
+			answers <- quorumAnswer[T]{hc: hc, result: result, err: err}
+		}(hc)
+	}
+
+	var (
+		collected []quorumAnswer[T]
+		votes     = make(map[string]int)
+		lastErr   error
+	)
+	for range clients {
+		a := <-answers
+		if a.err != nil {
+			lastErr = a.err
+			continue
+		}
+		collected = append(collected, a)
+		votes[key(a.result)]++
+	}
+
+	for _, a := range collected {
+		winner := key(a.result)
+		if votes[winner] < k {
+			continue
+		}
+
+		for _, other := range collected {
+			if key(other.result) != winner {
+				c.markDissent(other.hc, method)
+			}
+		}
+		return a.result, nil
+	}
+
+	if lastErr != nil {
+		return zero, fmt.Errorf("%w for %s: %d of %d endpoints agree, last error: %v", ErrNoQuorum, method, maxVotes(votes), k, lastErr)
+	}
+	return zero, fmt.Errorf("%w for %s: %d of %d endpoints agree", ErrNoQuorum, method, maxVotes(votes), k)
+}
+
+// quorumClients returns up to n usable HTTP endpoints that support method
+// and the block tag, if any, fastest first.
+func (c *Client) quorumClients(n int, method string, tag string) []*HealthyClient {
+	var clients []*HealthyClient
+	for _, hc := range c.httpSnapshot() {
+		if hc.usable() && hc.caps.supports([]string{method}, tag) {
+			clients = append(clients, hc)
+		}
+	}
+
+	slices.SortFunc(clients, func(a, b *HealthyClient) int {
+		switch {
+		case a.latencyEWMA() < b.latencyEWMA():
+			return -1
+		case a.latencyEWMA() > b.latencyEWMA():
+			return 1
+		default:
+			return 0
+		}
+	})
+
+	return clients[:min(n, len(clients))]
+}
+
+// markDissent records that hc disagreed with the quorum and takes it out of
+// rotation until the next successful health check.
+func (c *Client) markDissent(hc *HealthyClient, method string) {
+	hc.dissents.Add(1)
+	hc.breaker.onFailure(breakerCall{})
+	c.markUnhealthy(hc, fmt.Errorf("answer to %s disagrees with quorum", method))
+	log.Printf("Endpoint %s disagreed with quorum on %s (%d dissents)", hc.endpoint, method, hc.dissents.Load())
+}
+
+func maxVotes(votes map[string]int) int {
+	best := 0
+	for _, v := range votes {
+		best = max(best, v)
+	}
+	return best
+}
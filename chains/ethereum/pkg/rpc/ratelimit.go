@@ -0,0 +1,148 @@
+package rpc
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// defaultMethodCost is charged for methods missing from methodCosts.
+const defaultMethodCost = 20
+
+// methodCosts is the number of compute units each JSON-RPC method consumes
+// from an endpoint's budget, modelled on the pricing of hosted providers.
+// Providers price methods differently, so entries can be overridden through
+// the config and per endpoint.
+var methodCosts = map[string]int{
+	"eth_chainId":               1,
+	"eth_blockNumber":           10,
+	"eth_getBlockByNumber":      16,
+	"eth_getBlockByHash":        16,
+	"eth_getTransactionReceipt": 15,
+	"eth_getBlockReceipts":      500,
+	"eth_getBalance":            19,
+	"eth_getCode":               26,
+	"eth_call":                  26,
+	"eth_subscribe":             10,
+}
+
+func methodCost(method string) int {
+	if cost, ok := methodCosts[method]; ok {
+		return cost
+	}
+	return defaultMethodCost
+}
+
+// rateLimiter is a token bucket of compute units for a single endpoint.
+// A nil limiter, or one without a rate, never throttles but still counts
+// usage.
+type rateLimiter struct {
+	limiter *rate.Limiter
+	// costs overrides methodCosts for this endpoint.
+	costs map[string]int
+
+	mu        sync.Mutex
+	units     int64
+	methods   map[string]int64
+	throttled int64
+	waited    time.Duration
+}
+
+// newRateLimiter allows unitsPerSecond compute units per second with bursts
+// of up to burst units, charging methods by costs and then methodCosts. A
+// zero rate disables throttling.
+func newRateLimiter(unitsPerSecond float64, burst int, costs map[string]int) *rateLimiter {
+	rl := &rateLimiter{costs: costs, methods: make(map[string]int64)}
+	if unitsPerSecond > 0 {
+		if burst <= 0 {
+			burst = max(int(unitsPerSecond), rl.cost("eth_getBlockReceipts"))
+		}
+		rl.limiter = rate.NewLimiter(rate.Limit(unitsPerSecond), burst)
+	}
+	return rl
+}
+
+// cost returns the compute units a call to method consumes on the endpoint.
+func (rl *rateLimiter) cost(method string) int {
+	if cost, ok := rl.costs[method]; ok {
+		return cost
+	}
+	return methodCost(method)
+}
+
+// wait blocks until the endpoint's budget covers a call to each of methods.
+// Costs larger than the burst, such as big batches, are taken in
+// burst-sized steps so they are charged in full.
+func (rl *rateLimiter) wait(ctx context.Context, methods ...string) error {
+	cost := 0
+	for _, method := range methods {
+		cost += rl.cost(method)
+	}
+
+	if rl.limiter != nil {
+		start := time.Now()
+		for burst := rl.limiter.Burst(); cost > 0; cost -= burst {
+			if err := rl.limiter.WaitN(ctx, min(cost, burst)); err != nil {
+				return err
+			}
+		}
+
+		if waited := time.Since(start); waited > time.Millisecond {
+			rl.mu.Lock()
+			rl.throttled++
+			rl.waited += waited
+			rl.mu.Unlock()
+		}
+	}
+
+	for _, method := range methods {
+		rl.record(method, rl.cost(method))
+	}
+	return nil
+}
+
+// charge takes the cost of a call to method from the budget without
+// waiting, for background calls such as health checks.
+func (rl *rateLimiter) charge(method string) {
+	cost := rl.cost(method)
+	if rl.limiter != nil {
+		for n, burst := cost, rl.limiter.Burst(); n > 0; n -= burst {
+			rl.limiter.ReserveN(time.Now(), min(n, burst))
+		}
+	}
+	rl.record(method, cost)
+}
+
+func (rl *rateLimiter) record(method string, cost int) {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	rl.units += int64(cost)
+	rl.methods[method] += int64(cost)
+}
+
+func (rl *rateLimiter) status() map[string]interface{} {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	methods := make(map[string]int64, len(rl.methods))
+	for method, units := range rl.methods {
+		methods[method] = units
+	}
+
+	status := map[string]interface{}{
+		"units":     rl.units,
+		"methods":   methods,
+		"throttled": rl.throttled,
+		"waitMs":    rl.waited.Milliseconds(),
+	}
+
+	if rl.limiter != nil {
+		status["rate"] = float64(rl.limiter.Limit())
+		status["burst"] = rl.limiter.Burst()
+	}
+
+	return status
+}
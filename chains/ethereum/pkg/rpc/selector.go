@@ -0,0 +1,125 @@
+package rpc
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sync/atomic"
+
+	"github.com/al002/sylph/chains/ethereum/pkg/config"
+)
+
+// latencyAlpha is the weight of the newest sample in the latency EWMA.
+const latencyAlpha = 0.3
+
+// Selector picks the endpoint a call is sent to. clients is the full
+// endpoint list and current the rotation cursor that belongs to it. Select
+// returns nil when no endpoint is usable.
+type Selector interface {
+	Select(clients []*HealthyClient, current *atomic.Int32) *HealthyClient
+}
+
+func newSelector(strategy string) (Selector, error) {
+	switch strategy {
+	case config.StrategyRoundRobin, "":
+		return roundRobinSelector{}, nil
+	case config.StrategyLatency:
+		return latencySelector{}, nil
+	case config.StrategyWeighted:
+		return weightedSelector{}, nil
+	case config.StrategyPriority:
+		return prioritySelector{}, nil
+	default:
+		return nil, fmt.Errorf("unknown selection strategy: %s", strategy)
+	}
+}
+
+// roundRobinSelector stays on the current endpoint and moves on to the next
+// usable one when it stops being usable or NextClient is called.
+type roundRobinSelector struct{}
+
+func (roundRobinSelector) Select(clients []*HealthyClient, current *atomic.Int32) *HealthyClient {
+	return selectFrom(clients, current, func(*HealthyClient) bool { return true })
+}
+
+// latencySelector prefers the endpoint with the lowest latency EWMA.
+type latencySelector struct{}
+
+func (latencySelector) Select(clients []*HealthyClient, _ *atomic.Int32) *HealthyClient {
+	var best *HealthyClient
+	for _, hc := range clients {
+		if hc.usable() && (best == nil || hc.latencyEWMA() < best.latencyEWMA()) {
+			best = hc
+		}
+	}
+	return best
+}
+
+// weightedSelector picks a random endpoint, weighted by the success rate of
+// the requests sent to it.
+type weightedSelector struct{}
+
+func (weightedSelector) Select(clients []*HealthyClient, _ *atomic.Int32) *HealthyClient {
+	var (
+		candidates []*HealthyClient
+		weights    []float64
+		total      float64
+	)
+
+	for _, hc := range clients {
+		if hc.usable() {
+			w := hc.successRate()
+			candidates = append(candidates, hc)
+			weights = append(weights, w)
+			total += w
+		}
+	}
+
+	if len(candidates) == 0 {
+		return nil
+	}
+
+	r := rand.Float64() * total
+	for i, w := range weights {
+		if r < w {
+			return candidates[i]
+		}
+		r -= w
+	}
+	return candidates[len(candidates)-1]
+}
+
+// prioritySelector only uses endpoints of the best usable priority tier and
+// rotates within the tier like roundRobinSelector.
+type prioritySelector struct{}
+
+func (prioritySelector) Select(clients []*HealthyClient, current *atomic.Int32) *HealthyClient {
+	best := math.MaxInt
+	for _, hc := range clients {
+		if hc.usable() && hc.priority < best {
+			best = hc.priority
+		}
+	}
+
+	return selectFrom(clients, current, func(hc *HealthyClient) bool { return hc.priority == best })
+}
+
+// selectFrom returns the first usable client at or after current that
+// matches filter, and moves current to it.
+func selectFrom(clients []*HealthyClient, current *atomic.Int32, filter func(*HealthyClient) bool) *HealthyClient {
+	if len(clients) == 0 {
+		return nil
+	}
+
+	cur := int(uint32(current.Load()))
+
+	for offset := 0; offset < len(clients); offset++ {
+		idx := (cur + offset) % len(clients)
+		if hc := clients[idx]; hc.usable() && filter(hc) {
+			current.Store(int32(idx))
+			return hc
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,288 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+
+	"github.com/al002/sylph/chains/ethereum/pkg/pb"
+	"github.com/al002/sylph/chains/ethereum/pkg/rpc"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	gethrpc "github.com/ethereum/go-ethereum/rpc"
+)
+
+// rpcBlock holds the parts of an eth_getBlockByNumber result that are not
+// part of the header.
+type rpcBlock struct {
+	Hash         common.Hash          `json:"hash"`
+	Transactions []*types.Transaction `json:"transactions"`
+	Withdrawals  []*types.Withdrawal  `json:"withdrawals"`
+}
+
+// blockSource sends the requests of a block fetch, either through the
+// failover of rpc.Client or to a single endpoint pinned for the fetch.
+type blockSource struct {
+	batch        func(context.Context, []gethrpc.BatchElem) error
+	receiptsByTx func(context.Context, *types.Block) ([]*types.Receipt, error)
+}
+
+// failover returns a blockSource whose requests may each go to a different
+// endpoint.
+func (s *EthereumService) failover() blockSource {
+	return blockSource{
+		batch:        s.client.BatchCall,
+		receiptsByTx: s.getTransactionReceipts,
+	}
+}
+
+// pinned returns a blockSource whose requests all go to client.
+func (s *EthereumService) pinned(client *ethclient.Client) blockSource {
+	return blockSource{
+		batch: func(ctx context.Context, elems []gethrpc.BatchElem) error {
+			return rpc.BatchCallOn(ctx, client, elems)
+		},
+		receiptsByTx: func(ctx context.Context, block *types.Block) ([]*types.Receipt, error) {
+			receipts, err := transactionReceiptsFrom(ctx, client, block)
+			if err == nil && s.verifyBlocks {
+				err = verifyReceipts(block, receipts)
+			}
+			return receipts, err
+		},
+	}
+}
+
+// rangeChunk is the outcome of fetching a range of blocks with batches.
+// errs[i] is set for every block whose batch element failed.
+type rangeChunk struct {
+	blocks   []*types.Block
+	receipts [][]*types.Receipt
+	errs     []error
+}
+
+// fetchBlockRange fetches the blocks from..to (inclusive) and returns one
+// result per block in ascending order. Blocks are fetched with two JSON-RPC
+// batches, one for the blocks and one for their receipts, both sent to the
+// same endpoint when stickyChunks is set. Blocks whose batch element failed
+// are fetched again on their own, so a single bad element does not fail the
+// rest of the batch. With quorum set every block is fetched through a
+// quorum read instead.
+func (s *EthereumService) fetchBlockRange(ctx context.Context, from, to int64, quorum bool) []rangeResult {
+	results := make([]rangeResult, 0, to-from+1)
+
+	// Batches go to a single endpoint, so quorum reads fetch block by block.
+	if s.batchSize < 2 || quorum {
+		for num := from; num <= to; num++ {
+			blockData, err := s.fetchBlockData(ctx, big.NewInt(num), quorum)
+			results = append(results, rangeResult{num: num, blockData: blockData, err: err})
+		}
+		return results
+	}
+
+	// Only the part of the chunk between the first and the last block
+	// missing from the cache is fetched.
+	cached := make(map[int64]*pb.BlockData)
+	for num := from; num <= to; num++ {
+		if blockData, ok := s.cache.get(num); ok {
+			cached[num] = blockData
+		}
+	}
+
+	lo, hi := from, to
+	for lo <= hi && cached[lo] != nil {
+		lo++
+	}
+	for hi >= lo && cached[hi] != nil {
+		hi--
+	}
+
+	var (
+		chunk rangeChunk
+		err   error
+	)
+	switch {
+	case lo > hi:
+	case s.stickyChunks:
+		chunk, err = rpc.Session(ctx, s.client, []string{"eth_getBlockByNumber", "eth_getBlockReceipts"}, func(ctx context.Context, client *ethclient.Client) (rangeChunk, error) {
+			return s.batchChunk(ctx, s.pinned(client), lo, hi)
+		})
+	default:
+		chunk, err = s.batchChunk(ctx, s.failover(), lo, hi)
+	}
+
+	for num := from; num <= to; num++ {
+		if blockData, ok := cached[num]; ok {
+			results = append(results, rangeResult{num: num, blockData: blockData})
+			continue
+		}
+
+		r := rangeResult{num: num, err: err}
+		if r.err == nil {
+			i := num - lo
+			r.err = chunk.errs[i]
+			if r.err == nil {
+				r.blockData, r.err = s.buildBlockData(chunk.blocks[i], chunk.receipts[i])
+			}
+			if r.err == nil {
+				s.cache.add(r.blockData)
+			}
+		}
+
+		if r.err != nil && ctx.Err() == nil {
+			r.blockData, r.err = s.fetchBlockData(ctx, big.NewInt(r.num), false)
+		}
+
+		results = append(results, r)
+	}
+
+	return results
+}
+
+// batchChunk fetches the blocks from..to and their receipts through src. It
+// fails only when a batch failed as a whole.
+func (s *EthereumService) batchChunk(ctx context.Context, src blockSource, from, to int64) (rangeChunk, error) {
+	blocks, errs, err := s.batchBlocks(ctx, src, from, to)
+	if err != nil {
+		return rangeChunk{}, err
+	}
+
+	receipts, receiptErrs, err := s.batchReceipts(ctx, src, blocks)
+	if err != nil {
+		return rangeChunk{}, err
+	}
+
+	for i := range errs {
+		if errs[i] == nil {
+			errs[i] = receiptErrs[i]
+		}
+	}
+
+	return rangeChunk{blocks: blocks, receipts: receipts, errs: errs}, nil
+}
+
+// batchBlocks fetches the blocks from..to with full transactions in a single
+// batch. errs[i] is set for every block that could not be fetched.
+func (s *EthereumService) batchBlocks(ctx context.Context, src blockSource, from, to int64) ([]*types.Block, []error, error) {
+	n := int(to - from + 1)
+	raw := make([]json.RawMessage, n)
+	elems := make([]gethrpc.BatchElem, n)
+	for i := range elems {
+		elems[i] = gethrpc.BatchElem{
+			Method: "eth_getBlockByNumber",
+			Args:   []interface{}{hexutil.EncodeBig(big.NewInt(from + int64(i))), true},
+			Result: &raw[i],
+		}
+	}
+
+	if err := src.batch(ctx, elems); err != nil {
+		return nil, nil, err
+	}
+
+	blocks := make([]*types.Block, n)
+	errs := make([]error, n)
+	for i, elem := range elems {
+		if elem.Error != nil {
+			errs[i] = elem.Error
+			continue
+		}
+		blocks[i], errs[i] = decodeBlock(raw[i], s.verifyBlocks)
+	}
+
+	return blocks, errs, nil
+}
+
+// batchReceipts fetches the receipts of every non-nil block in a single
+// batch. errs[i] is set for every block whose receipts are missing. Blocks
+// are fetched one transaction at a time when the endpoint does not provide
+// eth_getBlockReceipts.
+func (s *EthereumService) batchReceipts(ctx context.Context, src blockSource, blocks []*types.Block) ([][]*types.Receipt, []error, error) {
+	receipts := make([][]*types.Receipt, len(blocks))
+	errs := make([]error, len(blocks))
+
+	var (
+		elems   []gethrpc.BatchElem
+		indexes []int
+	)
+	for i, block := range blocks {
+		if block == nil {
+			errs[i] = ethereum.NotFound
+			continue
+		}
+		elems = append(elems, gethrpc.BatchElem{
+			Method: "eth_getBlockReceipts",
+			Args:   []interface{}{block.Hash().Hex()},
+			Result: &receipts[i],
+		})
+		indexes = append(indexes, i)
+	}
+
+	if len(elems) == 0 {
+		return receipts, errs, nil
+	}
+
+	batchErr := src.batch(ctx, elems)
+	if batchErr != nil && !rpc.IsMethodNotFound(batchErr) {
+		return nil, nil, batchErr
+	}
+
+	for j, elem := range elems {
+		i := indexes[j]
+		errs[i] = elem.Error
+		if batchErr != nil {
+			errs[i] = batchErr
+		}
+		if errs[i] == nil && s.verifyBlocks {
+			errs[i] = verifyReceipts(blocks[i], receipts[i])
+		}
+
+		// The endpoint does not provide eth_getBlockReceipts.
+		if rpc.IsMethodNotFound(errs[i]) {
+			receipts[i], errs[i] = src.receiptsByTx(ctx, blocks[i])
+		}
+	}
+
+	return receipts, errs, nil
+}
+
+// decodeBlock decodes an eth_getBlockByNumber result with full transactions.
+// Uncle headers are not fetched; the block hash does not depend on them.
+// With verify set the block is checked with verifyBody.
+func decodeBlock(raw json.RawMessage, verify bool) (*types.Block, error) {
+	if len(raw) == 0 || string(raw) == "null" {
+		return nil, ethereum.NotFound
+	}
+
+	var header types.Header
+	if err := json.Unmarshal(raw, &header); err != nil {
+		return nil, err
+	}
+
+	var body rpcBlock
+	if err := json.Unmarshal(raw, &body); err != nil {
+		return nil, err
+	}
+
+	if header.TxHash == types.EmptyTxsHash && len(body.Transactions) > 0 {
+		return nil, fmt.Errorf("server returned non-empty transaction list but block header indicates no transactions")
+	}
+	if header.TxHash != types.EmptyTxsHash && len(body.Transactions) == 0 {
+		return nil, fmt.Errorf("server returned empty transaction list but block header indicates transactions")
+	}
+
+	block := types.NewBlockWithHeader(&header).WithBody(types.Body{
+		Transactions: body.Transactions,
+		Withdrawals:  body.Withdrawals,
+	})
+
+	if verify {
+		if err := verifyBody(block, body.Hash); err != nil {
+			return nil, err
+		}
+	}
+
+	return block, nil
+}
@@ -0,0 +1,130 @@
+package service
+
+import (
+	"container/list"
+	"sync"
+	"sync/atomic"
+
+	"github.com/al002/sylph/chains/ethereum/pkg/pb"
+)
+
+// blockKey identifies a cached block.
+type blockKey struct {
+	number int64
+	hash   string
+}
+
+// blockCache is an LRU cache of converted blocks. Only blocks at least
+// confirmations behind the best known head are cached: they are not
+// expected to be reorged, so a cached block is served by number without
+// asking a node for its hash first. A nil cache is disabled.
+type blockCache struct {
+	// head returns the best known head, BestHead of the service's client.
+	head          func() uint64
+	size          int
+	confirmations int64
+
+	mu       sync.Mutex
+	entries  map[blockKey]*list.Element
+	byNumber map[int64]blockKey
+	lru      *list.List // of *pb.BlockData, most recently used first
+
+	hits   atomic.Int64
+	misses atomic.Int64
+}
+
+func newBlockCache(head func() uint64, size, confirmations int) *blockCache {
+	if size <= 0 {
+		return nil
+	}
+
+	return &blockCache{
+		head:          head,
+		size:          size,
+		confirmations: int64(confirmations),
+		entries:       make(map[blockKey]*list.Element, size),
+		byNumber:      make(map[int64]blockKey, size),
+		lru:           list.New(),
+	}
+}
+
+// settled reports whether block num is deep enough to be cached.
+func (c *blockCache) settled(num int64) bool {
+	return num <= int64(c.head())-c.confirmations
+}
+
+func (c *blockCache) get(num int64) (*pb.BlockData, bool) {
+	if c == nil || !c.settled(num) {
+		return nil, false
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	key, ok := c.byNumber[num]
+	if !ok {
+		c.misses.Add(1)
+		return nil, false
+	}
+
+	elem := c.entries[key]
+	c.lru.MoveToFront(elem)
+	c.hits.Add(1)
+	return elem.Value.(*pb.BlockData), true
+}
+
+func (c *blockCache) add(blockData *pb.BlockData) {
+	if c == nil || blockData == nil || !c.settled(blockData.Block.BlockNumber) {
+		return
+	}
+
+	key := blockKey{number: blockData.Block.BlockNumber, hash: blockData.Block.Hash}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.entries[key]; ok {
+		c.lru.MoveToFront(elem)
+		return
+	}
+
+	// A settled block should never change, but if it did the newer answer
+	// replaces the old one.
+	if old, ok := c.byNumber[key.number]; ok {
+		c.lru.Remove(c.entries[old])
+		delete(c.entries, old)
+	}
+
+	c.entries[key] = c.lru.PushFront(blockData)
+	c.byNumber[key.number] = key
+
+	for c.lru.Len() > c.size {
+		oldest := c.lru.Back().Value.(*pb.BlockData)
+		c.lru.Remove(c.lru.Back())
+
+		oldKey := blockKey{number: oldest.Block.BlockNumber, hash: oldest.Block.Hash}
+		delete(c.entries, oldKey)
+		if c.byNumber[oldKey.number] == oldKey {
+			delete(c.byNumber, oldKey.number)
+		}
+	}
+}
+
+func (c *blockCache) status() map[string]interface{} {
+	if c == nil {
+		return map[string]interface{}{"enabled": false}
+	}
+
+	c.mu.Lock()
+	entries := c.lru.Len()
+	c.mu.Unlock()
+
+	return map[string]interface{}{
+		"enabled":       true,
+		"size":          c.size,
+		"confirmations": c.confirmations,
+		"entries":       entries,
+		"hits":          c.hits.Load(),
+		"misses":        c.misses.Load(),
+	}
+}
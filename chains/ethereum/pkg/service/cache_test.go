@@ -0,0 +1,109 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/al002/sylph/chains/ethereum/pkg/pb"
+)
+
+// cacheOp adds the block num of fork to the cache, or gets block num when
+// fork is empty.
+type cacheOp struct {
+	num  int64
+	fork string
+}
+
+func TestBlockCache(t *testing.T) {
+	tests := []struct {
+		name string
+		size int
+		ops  []cacheOp
+		// want maps every block number checked afterwards to the fork of the
+		// cached block, or to "" if it must be missing.
+		want map[int64]string
+	}{
+		{
+			name: "evicts the oldest block",
+			size: 2,
+			ops:  []cacheOp{{1, "a"}, {2, "a"}, {3, "a"}},
+			want: map[int64]string{1: "", 2: "a", 3: "a"},
+		},
+		{
+			name: "evicts the least recently used block",
+			size: 2,
+			ops:  []cacheOp{{1, "a"}, {2, "a"}, {1, ""}, {3, "a"}},
+			want: map[int64]string{1: "a", 2: "", 3: "a"},
+		},
+		{
+			name: "re-adding a block marks it used",
+			size: 2,
+			ops:  []cacheOp{{1, "a"}, {2, "a"}, {1, "a"}, {3, "a"}},
+			want: map[int64]string{1: "a", 2: "", 3: "a"},
+		},
+		{
+			name: "replaces a block by number",
+			size: 2,
+			ops:  []cacheOp{{1, "a"}, {1, "b"}},
+			want: map[int64]string{1: "b"},
+		},
+		{
+			name: "replaced block leaves no entry to evict",
+			size: 2,
+			ops:  []cacheOp{{1, "a"}, {2, "a"}, {1, "b"}, {3, "a"}},
+			want: map[int64]string{1: "b", 2: "", 3: "a"},
+		},
+		{
+			name: "ignores blocks that are not settled",
+			size: 2,
+			ops:  []cacheOp{{95, "a"}, {96, "a"}},
+			want: map[int64]string{95: "a", 96: ""},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Blocks up to 95 are settled.
+			c := newBlockCache(func() uint64 { return 100 }, tt.size, 5)
+
+			for _, op := range tt.ops {
+				if op.fork == "" {
+					c.get(op.num)
+					continue
+				}
+				c.add(&pb.BlockData{Block: testBlock(op.num, op.fork)})
+			}
+
+			cached := 0
+			for num, fork := range tt.want {
+				blockData, ok := c.get(num)
+				switch {
+				case fork == "" && ok:
+					t.Errorf("block %d cached, want missing", num)
+				case fork != "" && !ok:
+					t.Errorf("block %d missing, want cached", num)
+				case ok && blockData.Block.Hash != testBlock(num, fork).Hash:
+					t.Errorf("block %d has hash %s, want %s", num, blockData.Block.Hash, testBlock(num, fork).Hash)
+				}
+				if fork != "" {
+					cached++
+				}
+			}
+
+			if entries := c.lru.Len(); entries != cached {
+				t.Errorf("%d entries, want %d", entries, cached)
+			}
+			if len(c.entries) != len(c.byNumber) {
+				t.Errorf("%d entries indexed by number, want %d", len(c.byNumber), len(c.entries))
+			}
+		})
+	}
+}
+
+func TestBlockCacheDisabled(t *testing.T) {
+	c := newBlockCache(func() uint64 { return 100 }, 0, 5)
+	c.add(&pb.BlockData{Block: testBlock(1, "a")})
+
+	if _, ok := c.get(1); ok {
+		t.Error("disabled cache returned a block")
+	}
+}
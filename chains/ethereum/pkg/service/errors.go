@@ -0,0 +1,34 @@
+package service
+
+import (
+	"errors"
+
+	"github.com/al002/sylph/chains/ethereum/pkg/pb"
+	"github.com/al002/sylph/chains/ethereum/pkg/rpc"
+	"github.com/ethereum/go-ethereum"
+)
+
+// errReceiptsMismatch is returned when the receipts of a block do not line up
+// with its transactions, usually because the node answered from another fork.
+var errReceiptsMismatch = errors.New("receipts count mismatch")
+
+// blockErrorEvent reports a block that could not be fetched to streams that
+// asked for errors to be reported.
+func blockErrorEvent(num int64, err error) *pb.BlockEvent {
+	return &pb.BlockEvent{
+		Event: &pb.BlockEvent_BlockError{BlockError: &pb.BlockError{
+			BlockNumber: num,
+			Reason:      err.Error(),
+			Retryable:   isRetryable(err),
+		}},
+	}
+}
+
+// isRetryable reports whether fetching the block again may succeed.
+func isRetryable(err error) bool {
+	if errors.Is(err, ethereum.NotFound) || errors.Is(err, errReceiptsMismatch) {
+		return true
+	}
+
+	return rpc.IsRetryable(err)
+}
@@ -0,0 +1,214 @@
+package service
+
+import (
+	"context"
+	"log"
+	"sync"
+
+	"github.com/al002/sylph/chains/ethereum/pkg/config"
+	"github.com/al002/sylph/chains/ethereum/pkg/pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// maxHeaderFailures is the number of heads in a row whose confirmed header
+// cannot be fetched before a follower gives up and ends its subscribers'
+// streams.
+const maxHeaderFailures = 3
+
+// followerKey identifies the head a follower tracks. Subscribers asking for
+// the same confirmation mode share a follower.
+type followerKey struct {
+	confirmation  pb.BlockConfirmation
+	confirmations uint32
+}
+
+// follower tracks one head of the chain for all SubscribeNewBlocks calls
+// that follow it. Each block is fetched once and its events are fanned out
+// to every subscriber through a bounded buffer.
+type follower struct {
+	s      *EthereumService
+	key    followerKey
+	cancel context.CancelFunc
+
+	mu   sync.Mutex
+	subs map[*subscriber]struct{}
+}
+
+// subscriber receives the events of a follower. events is closed by the
+// follower when the subscriber is disconnected, with err set to the reason.
+type subscriber struct {
+	events chan *pb.BlockEvent
+	err    error
+
+	// done is closed by the subscriber when it goes away, so a follower
+	// blocked on a full buffer lets go of it.
+	done chan struct{}
+}
+
+// followers holds the running followers of a service.
+type followers struct {
+	mu     sync.Mutex
+	active map[followerKey]*follower
+
+	bufferSize int
+	policy     string
+}
+
+func newFollowers(cfg *config.Config) *followers {
+	return &followers{
+		active:     make(map[followerKey]*follower),
+		bufferSize: cfg.SubscriberBufferSize,
+		policy:     cfg.SlowConsumerPolicy,
+	}
+}
+
+// subscribe adds a subscriber to the follower for key, starting the
+// follower if it is not running yet.
+func (fs *followers) subscribe(s *EthereumService, key followerKey) (*follower, *subscriber) {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+
+	f, ok := fs.active[key]
+	if !ok {
+		ctx, cancel := context.WithCancel(context.Background())
+		f = &follower{
+			s:      s,
+			key:    key,
+			cancel: cancel,
+			subs:   make(map[*subscriber]struct{}),
+		}
+		fs.active[key] = f
+		go f.run(ctx)
+	}
+
+	sub := &subscriber{
+		events: make(chan *pb.BlockEvent, fs.bufferSize),
+		done:   make(chan struct{}),
+	}
+
+	f.mu.Lock()
+	f.subs[sub] = struct{}{}
+	f.mu.Unlock()
+
+	return f, sub
+}
+
+// unsubscribe removes a subscriber and stops the follower once it has none
+// left.
+func (fs *followers) unsubscribe(f *follower, sub *subscriber) {
+	close(sub.done)
+
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+
+	f.mu.Lock()
+	delete(f.subs, sub)
+	empty := len(f.subs) == 0
+	f.mu.Unlock()
+
+	// The follower may already have been replaced by a new one for the
+	// same key if its other subscribers left earlier.
+	if empty && fs.active[f.key] == f {
+		delete(fs.active, f.key)
+		f.cancel()
+	}
+}
+
+// run follows the head until ctx is cancelled. Blocks that cannot be
+// fetched are broadcast as errors; subscribers that did not ask for them
+// end their stream on their own. When the follower cannot roll back a
+// reorg it starts over at the next head, and subscribers fill the gap
+// themselves. When the confirmed header cannot be fetched for
+// maxHeaderFailures heads in a row, every subscriber's stream ends.
+func (f *follower) run(ctx context.Context) {
+	heads := f.s.client.SubscribeHeads(ctx)
+	bs := newBlockStream(f.s, f.broadcast, 0, true)
+
+	failures := 0
+	for header := range heads {
+		target, err := f.s.confirmedHeader(ctx, header, f.key.confirmation, f.key.confirmations)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+
+			log.Printf("Failed to fetch confirmed header: %v", err)
+			if failures++; failures >= maxHeaderFailures {
+				f.fail(status.Errorf(codes.Internal, "failed to fetch confirmed header: %v", err))
+				return
+			}
+			continue
+		}
+		failures = 0
+
+		// The tip is not yet confirmations blocks past genesis.
+		if target == nil {
+			continue
+		}
+
+		if err := bs.handleHeader(ctx, target); err != nil && ctx.Err() == nil {
+			log.Printf("Head follower restarting after error: %v", err)
+			bs = newBlockStream(f.s, f.broadcast, 0, true)
+		}
+	}
+}
+
+// fail stops the follower and ends the stream of every subscriber with err.
+// The next subscriber starts a new follower.
+func (f *follower) fail(err error) {
+	fs := f.s.followers
+	fs.mu.Lock()
+	if fs.active[f.key] == f {
+		delete(fs.active, f.key)
+	}
+	fs.mu.Unlock()
+	f.cancel()
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for sub := range f.subs {
+		sub.err = err
+		close(sub.events)
+		delete(f.subs, sub)
+	}
+}
+
+// broadcast hands event to every subscriber, applying the slow consumer
+// policy to those whose buffer is full. Blocking sends happen after f.mu
+// is released so a slow subscriber does not hold up others joining or
+// leaving.
+func (f *follower) broadcast(event *pb.BlockEvent) error {
+	var blocked []*subscriber
+
+	f.mu.Lock()
+	for sub := range f.subs {
+		select {
+		case sub.events <- event:
+			continue
+		default:
+		}
+
+		switch f.s.followers.policy {
+		case config.SlowConsumerDrop:
+			// The subscriber fetches what it missed once it catches up.
+		case config.SlowConsumerBlock:
+			blocked = append(blocked, sub)
+		default:
+			sub.err = status.Error(codes.ResourceExhausted, "subscriber too slow, disconnected")
+			close(sub.events)
+			delete(f.subs, sub)
+		}
+	}
+	f.mu.Unlock()
+
+	for _, sub := range blocked {
+		select {
+		case sub.events <- event:
+		case <-sub.done:
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,84 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/al002/sylph/chains/ethereum/pkg/pb"
+	"github.com/al002/sylph/chains/ethereum/pkg/rpc"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/trie"
+)
+
+// fetchBlockDataQuorum fetches a block once a quorum of endpoints agrees on
+// its hash. The body is then fetched by that hash and checked against it,
+// which pins it to the agreed block. Receipts are also put to a quorum when
+// quorumReceipts is set.
+func (s *EthereumService) fetchBlockDataQuorum(ctx context.Context, blockNum *big.Int) (*pb.BlockData, error) {
+	header, err := rpc.Quorum(ctx, s.client, "eth_getBlockByNumber", s.quorumSize, s.quorumThreshold,
+		func(ctx context.Context, client *ethclient.Client) (*types.Header, error) {
+			return client.HeaderByNumber(ctx, blockNum)
+		},
+		func(header *types.Header) string {
+			return header.Hash().Hex()
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := s.fetchBlockByHash(ctx, header.Hash())
+	if err != nil {
+		return nil, err
+	}
+
+	var receipts []*types.Receipt
+	if s.quorumReceipts {
+		receipts, err = s.getBlockReceiptsQuorum(ctx, block)
+	} else {
+		receipts, err = s.getBlockReceipts(ctx, block)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get block receipts: %w", err)
+	}
+
+	return s.buildBlockData(block, receipts)
+}
+
+// getBlockReceiptsQuorum fetches the receipts of a block once a quorum of
+// endpoints agrees on them, comparing their receipts root. Receipts are
+// fetched one transaction at a time when eth_getBlockReceipts is not
+// available.
+func (s *EthereumService) getBlockReceiptsQuorum(ctx context.Context, block *types.Block) ([]*types.Receipt, error) {
+	receiptsRoot := func(receipts []*types.Receipt) string {
+		return types.DeriveSha(types.Receipts(receipts), trie.NewStackTrie(nil)).Hex()
+	}
+
+	receipts, err := rpc.Quorum(ctx, s.client, "eth_getBlockReceipts", s.quorumSize, s.quorumThreshold,
+		func(ctx context.Context, client *ethclient.Client) ([]*types.Receipt, error) {
+			var receipts []*types.Receipt
+			err := client.Client().CallContext(ctx, &receipts, "eth_getBlockReceipts", block.Hash().Hex())
+			if err == nil && s.verifyBlocks {
+				err = verifyReceipts(block, receipts)
+			}
+			return receipts, err
+		},
+		receiptsRoot,
+	)
+	if !rpc.IsMethodNotFound(err) {
+		return receipts, err
+	}
+
+	return rpc.Quorum(ctx, s.client, "eth_getTransactionReceipt", s.quorumSize, s.quorumThreshold,
+		func(ctx context.Context, client *ethclient.Client) ([]*types.Receipt, error) {
+			receipts, err := transactionReceiptsFrom(ctx, client, block)
+			if err == nil && s.verifyBlocks {
+				err = verifyReceipts(block, receipts)
+			}
+			return receipts, err
+		},
+		receiptsRoot,
+	)
+}
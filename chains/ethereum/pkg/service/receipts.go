@@ -0,0 +1,129 @@
+package service
+
+import (
+	"context"
+	"sync"
+
+	"github.com/al002/sylph/chains/ethereum/pkg/rpc"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	gethrpc "github.com/ethereum/go-ethereum/rpc"
+)
+
+// receiptsBatchSize is the number of eth_getTransactionReceipt calls sent
+// per batch when receipts are fetched one transaction at a time.
+const receiptsBatchSize = 100
+
+// getBlockReceipts fetches the receipts of a block with eth_getBlockReceipts,
+// or one transaction at a time when no endpoint provides that method.
+func (s *EthereumService) getBlockReceipts(ctx context.Context, block *types.Block) ([]*types.Receipt, error) {
+	receipts, err := rpc.Call(ctx, s.client, "eth_getBlockReceipts", func(ctx context.Context, client *ethclient.Client) ([]*types.Receipt, error) {
+		var receipts []*types.Receipt
+		err := client.Client().CallContext(
+			ctx,
+			&receipts,
+			"eth_getBlockReceipts",
+			block.Hash().Hex(),
+		)
+		if err == nil && s.verifyBlocks {
+			err = verifyReceipts(block, receipts)
+		}
+		return receipts, err
+	})
+	if rpc.IsMethodNotFound(err) {
+		return s.getTransactionReceipts(ctx, block)
+	}
+
+	return receipts, err
+}
+
+// getTransactionReceipts fetches the receipts of a block one transaction at
+// a time, with batches of receiptsBatchSize calls sent concurrently.
+func (s *EthereumService) getTransactionReceipts(ctx context.Context, block *types.Block) ([]*types.Receipt, error) {
+	receipts, elems := receiptElems(block)
+
+	var (
+		wg   sync.WaitGroup
+		errs = make([]error, 0, len(elems)/receiptsBatchSize+1)
+		mu   sync.Mutex
+	)
+	for from := 0; from < len(elems); from += receiptsBatchSize {
+		wg.Add(1)
+		go func(chunk []gethrpc.BatchElem) {
+			defer wg.Done()
+			if err := s.client.BatchCall(ctx, chunk); err != nil {
+				mu.Lock()
+				errs = append(errs, err)
+				mu.Unlock()
+			}
+		}(elems[from:min(from+receiptsBatchSize, len(elems))])
+	}
+	wg.Wait()
+
+	if len(errs) > 0 {
+		return nil, errs[0]
+	}
+
+	if err := checkReceipts(block, receipts, elems); err != nil {
+		return nil, err
+	}
+
+	if s.verifyBlocks {
+		if err := verifyReceipts(block, receipts); err != nil {
+			return nil, err
+		}
+	}
+	return receipts, nil
+}
+
+// transactionReceiptsFrom fetches the receipts of a block one transaction at
+// a time from client, for fetches pinned to a single endpoint such as quorum
+// reads and the blockSource returned by pinned.
+func transactionReceiptsFrom(ctx context.Context, client *ethclient.Client, block *types.Block) ([]*types.Receipt, error) {
+	receipts, elems := receiptElems(block)
+	for from := 0; from < len(elems); from += receiptsBatchSize {
+		if err := rpc.BatchCallOn(ctx, client, elems[from:min(from+receiptsBatchSize, len(elems))]); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := checkReceipts(block, receipts, elems); err != nil {
+		return nil, err
+	}
+	return receipts, nil
+}
+
+// receiptElems returns an eth_getTransactionReceipt call for every
+// transaction of block, each decoding into its slot of receipts.
+func receiptElems(block *types.Block) ([]*types.Receipt, []gethrpc.BatchElem) {
+	txs := block.Transactions()
+	receipts := make([]*types.Receipt, len(txs))
+	elems := make([]gethrpc.BatchElem, len(txs))
+	for i, tx := range txs {
+		elems[i] = gethrpc.BatchElem{
+			Method: "eth_getTransactionReceipt",
+			Args:   []interface{}{tx.Hash().Hex()},
+			Result: &receipts[i],
+		}
+	}
+	return receipts, elems
+}
+
+// checkReceipts returns the first failed call, and rejects receipts that are
+// missing or belong to another block, which happens when the node answers
+// from another fork.
+func checkReceipts(block *types.Block, receipts []*types.Receipt, elems []gethrpc.BatchElem) error {
+	for i, elem := range elems {
+		if elem.Error != nil {
+			return elem.Error
+		}
+		if receipts[i] == nil {
+			return ethereum.NotFound
+		}
+		if receipts[i].BlockHash != block.Hash() {
+			return errReceiptsMismatch
+		}
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+package service
+
+import (
+	"sort"
+	"time"
+
+	"github.com/al002/sylph/chains/ethereum/pkg/pb"
+)
+
+// reorgWindowSize is the number of recently sent blocks kept for reorg
+// detection. Reorgs deeper than this cannot be fully rolled back.
+const reorgWindowSize = 128
+
+// A block whose parent does not match what was sent, while the sent parent
+// is still reported canonical, means the endpoints disagree about the
+// chain. The block is fetched again after reorgRetryDelay, up to
+// maxReorgRetries times before the stream gives up.
+const (
+	reorgRetryDelay = time.Second
+	maxReorgRetries = 5
+)
+
+// blockWindow tracks the most recently sent blocks of a stream so that a
+// parent hash mismatch can be traced back to the common ancestor.
+type blockWindow struct {
+	size   int
+	blocks map[int64]*pb.Block
+}
+
+func newBlockWindow(size int) *blockWindow {
+	return &blockWindow{
+		size:   size,
+		blocks: make(map[int64]*pb.Block, size),
+	}
+}
+
+func (w *blockWindow) get(num int64) (*pb.Block, bool) {
+	block, ok := w.blocks[num]
+	return block, ok
+}
+
+func (w *blockWindow) add(block *pb.Block) {
+	w.blocks[block.BlockNumber] = block
+	delete(w.blocks, block.BlockNumber-int64(w.size))
+}
+
+// removeAbove drops every block with a number greater than num and returns
+// them newest first.
+func (w *blockWindow) removeAbove(num int64) []*pb.Block {
+	var removed []*pb.Block
+	for n, block := range w.blocks {
+		if n > num {
+			removed = append(removed, block)
+			delete(w.blocks, n)
+		}
+	}
+
+	sort.Slice(removed, func(i, j int) bool {
+		return removed[i].BlockNumber > removed[j].BlockNumber
+	})
+	return removed
+}
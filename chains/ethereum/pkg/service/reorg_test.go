@@ -0,0 +1,138 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/al002/sylph/chains/ethereum/pkg/pb"
+)
+
+func testBlock(num int64, fork string) *pb.Block {
+	return &pb.Block{
+		BlockNumber: num,
+		Hash:        fmt.Sprintf("%s%d", fork, num),
+		ParentHash:  fmt.Sprintf("%s%d", fork, num-1),
+	}
+}
+
+func testWindow(size int, from, to int64) *blockWindow {
+	w := newBlockWindow(size)
+	for num := from; num <= to; num++ {
+		w.add(testBlock(num, "a"))
+	}
+	return w
+}
+
+func blockNumbers(blocks []*pb.Block) []int64 {
+	nums := make([]int64, len(blocks))
+	for i, block := range blocks {
+		nums[i] = block.BlockNumber
+	}
+	return nums
+}
+
+func TestBlockWindowRemoveAbove(t *testing.T) {
+	tests := []struct {
+		name     string
+		size     int
+		from, to int64
+		above    int64
+		removed  []int64
+		kept     []int64
+	}{
+		{name: "none above", size: 10, from: 1, to: 5, above: 5, removed: []int64{}, kept: []int64{1, 2, 3, 4, 5}},
+		{name: "newest first", size: 10, from: 1, to: 5, above: 2, removed: []int64{5, 4, 3}, kept: []int64{1, 2}},
+		{name: "all", size: 10, from: 1, to: 5, above: 0, removed: []int64{5, 4, 3, 2, 1}, kept: []int64{}},
+		{name: "evicted blocks are gone", size: 3, from: 1, to: 5, above: 0, removed: []int64{5, 4, 3}, kept: []int64{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := testWindow(tt.size, tt.from, tt.to)
+
+			if got := blockNumbers(w.removeAbove(tt.above)); !slices.Equal(got, tt.removed) {
+				t.Errorf("removeAbove(%d) = %v, want %v", tt.above, got, tt.removed)
+			}
+
+			kept := []int64{}
+			for num := tt.from; num <= tt.to; num++ {
+				if _, ok := w.get(num); ok {
+					kept = append(kept, num)
+				}
+			}
+			if !slices.Equal(kept, tt.kept) {
+				t.Errorf("kept %v, want %v", kept, tt.kept)
+			}
+		})
+	}
+}
+
+func TestBlockStreamRollback(t *testing.T) {
+	errLookup := errors.New("lookup failed")
+
+	tests := []struct {
+		name string
+		// The chain forks onto "b" above forkAt; blocks at or below it are
+		// still canonical.
+		forkAt  int64
+		err     error
+		removed []int64
+		next    int64
+		wantErr error
+	}{
+		{name: "no reorg", forkAt: 14, removed: []int64{}, next: 15},
+		{name: "two blocks", forkAt: 12, removed: []int64{14, 13}, next: 13},
+		{name: "deeper than window", forkAt: 0, removed: []int64{14, 13, 12, 11, 10}, next: 10},
+		{name: "lookup error", forkAt: 12, err: errLookup, removed: []int64{}, next: 15, wantErr: errLookup},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var events []*pb.BlockEvent
+			bs := newBlockStream(nil, func(event *pb.BlockEvent) error {
+				events = append(events, event)
+				return nil
+			}, 15, false)
+			bs.window = testWindow(reorgWindowSize, 10, 14)
+			bs.canonicalHash = func(ctx context.Context, num int64) (string, error) {
+				if tt.err != nil {
+					return "", tt.err
+				}
+				if num > tt.forkAt {
+					return testBlock(num, "b").Hash, nil
+				}
+				return testBlock(num, "a").Hash, nil
+			}
+
+			err := bs.rollback(context.Background(), 14)
+			if tt.wantErr == nil && err != nil {
+				t.Fatalf("rollback: %v", err)
+			}
+			if tt.wantErr != nil && err == nil {
+				t.Fatalf("rollback succeeded, want error")
+			}
+
+			removed := []int64{}
+			for _, event := range events {
+				ev, ok := event.Event.(*pb.BlockEvent_BlockRemoved)
+				if !ok {
+					t.Fatalf("unexpected event %v", event)
+				}
+				if want := testBlock(ev.BlockRemoved.BlockNumber, "a").Hash; ev.BlockRemoved.Hash != want {
+					t.Errorf("removed hash %s, want %s", ev.BlockRemoved.Hash, want)
+				}
+				removed = append(removed, ev.BlockRemoved.BlockNumber)
+			}
+			if !slices.Equal(removed, tt.removed) {
+				t.Errorf("removed %v, want %v", removed, tt.removed)
+			}
+
+			if bs.next != tt.next {
+				t.Errorf("next = %d, want %d", bs.next, tt.next)
+			}
+		})
+	}
+}
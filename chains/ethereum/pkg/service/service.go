@@ -2,39 +2,82 @@ package service
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"math/big"
-	"sync"
 
+	"github.com/al002/sylph/chains/ethereum/pkg/config"
 	"github.com/al002/sylph/chains/ethereum/pkg/pb"
 	"github.com/al002/sylph/chains/ethereum/pkg/rpc"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	gethrpc "github.com/ethereum/go-ethereum/rpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
-   "google.golang.org/protobuf/types/known/emptypb"
 )
 
 type EthereumService struct {
 	pb.UnimplementedEthereumServiceServer
 	client *rpc.Client
 	signer types.Signer
+
+	// batchSize is the number of blocks GetBlockRange fetches per JSON-RPC
+	// batch. Values below 2 disable batching.
+	batchSize int
+
+	// Quorum reads settings, see config.Config.
+	quorumReads     bool
+	quorumSize      int
+	quorumThreshold int
+	quorumReceipts  bool
+
+	// stickyChunks sends both batches of a GetBlockRange chunk to the same
+	// endpoint.
+	stickyChunks bool
+
+	// verifyBlocks checks fetched blocks and receipts against the roots in
+	// their header, see verifyBody and verifyReceipts.
+	verifyBlocks bool
+
+	// cache holds settled blocks, nil when disabled.
+	cache *blockCache
+
+	// followers share head tracking between SubscribeNewBlocks calls.
+	followers *followers
 }
 
-func NewEthereumService(client *rpc.Client) (*EthereumService, error) {
+func NewEthereumService(client *rpc.Client, cfg *config.Config) (*EthereumService, error) {
 	chainID, err := client.ChainID()
 	if err != nil {
 		return nil, fmt.Errorf("failed to get chain ID: %w", err)
 	}
 
 	return &EthereumService{
-		client: client,
-		signer: types.NewLondonSigner(chainID),
+		client:    client,
+		signer:    types.NewLondonSigner(chainID),
+		batchSize: cfg.BatchSize,
+
+		quorumReads:     cfg.QuorumReads,
+		quorumSize:      cfg.QuorumSize,
+		quorumThreshold: cfg.QuorumThreshold,
+		quorumReceipts:  cfg.QuorumReceipts,
+
+		stickyChunks: cfg.StickyRangeChunks,
+		verifyBlocks: cfg.VerifyBlocks,
+
+		cache:     newBlockCache(client.BestHead, cfg.CacheSize, cfg.CacheConfirmations),
+		followers: newFollowers(cfg),
 	}, nil
 }
 
-func (s *EthereumService) GetLatestBlock(ctx context.Context, req *emptypb.Empty) (*pb.GetLatestBlockResponse, error) {
-	latestBlock, err := s.fetchLatestBlock(ctx)
+func (s *EthereumService) GetLatestBlock(ctx context.Context, req *pb.GetLatestBlockRequest) (*pb.GetLatestBlockResponse, error) {
+	if err := validateConfirmation(req.Confirmation); err != nil {
+		return nil, err
+	}
+
+	latestBlock, err := s.fetchLatestBlock(ctx, req.Confirmation, req.Confirmations)
 
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to fetch latest block: %v", err)
@@ -46,7 +89,7 @@ func (s *EthereumService) GetLatestBlock(ctx context.Context, req *emptypb.Empty
 }
 
 func (s *EthereumService) GetBlock(ctx context.Context, req *pb.GetBlockRequest) (*pb.GetBlockResponse, error) {
-	blockData, err := s.fetchBlockData(ctx, big.NewInt(req.BlockNumber))
+	blockData, err := s.fetchBlockData(ctx, big.NewInt(req.BlockNumber), req.Quorum || s.quorumReads)
 
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to fetch block: %v", err)
@@ -58,36 +101,53 @@ func (s *EthereumService) GetBlock(ctx context.Context, req *pb.GetBlockRequest)
 }
 
 func (s *EthereumService) SubscribeNewBlocks(req *pb.SubscribeNewBlocksRequest, stream pb.EthereumService_SubscribeNewBlocksServer) error {
-	ctx := stream.Context()
-	headers := make(chan *types.Header)
+	if req.StartBlock < 0 {
+		return status.Errorf(codes.InvalidArgument, "invalid start block: %d", req.StartBlock)
+	}
 
-	wsClient, err := s.client.WSClient()
-	if err != nil {
+	if err := validateConfirmation(req.Confirmation); err != nil {
 		return err
 	}
 
-	if wsClient == nil {
-		return status.Error(codes.Unavailable, "no WebSocket endpoints available")
-	}
+	ctx := stream.Context()
 
-	sub, err := wsClient.SubscribeNewHead(ctx, headers)
-	if err != nil {
-		return status.Errorf(codes.Internal, "failed to subscribe to new heads: %v", err)
+	bs := newBlockStream(s, func(event *pb.BlockEvent) error {
+		if err := stream.Send(event); err != nil {
+			return status.Errorf(codes.Internal, "failed to send block event: %v", err)
+		}
+		return nil
+	}, req.StartBlock, req.ReportErrors)
+
+	if req.StartBlock > 0 {
+		latestBlock, err := s.fetchLatestBlock(ctx, req.Confirmation, req.Confirmations)
+		if err != nil {
+			return status.Errorf(codes.Internal, "failed to fetch latest block: %v", err)
+		}
+
+		if err := bs.advance(ctx, latestBlock.BlockNumber); err != nil {
+			return err
+		}
 	}
-	defer sub.Unsubscribe()
+
+	// Join the follower only once the backfill is done, so a long backfill
+	// does not fill the buffer and trip the slow consumer policy. Blocks
+	// produced meanwhile are fetched by apply when the first event does
+	// not line up.
+	f, sub := s.followers.subscribe(s, followerKey{
+		confirmation:  req.Confirmation,
+		confirmations: req.Confirmations,
+	})
+	defer s.followers.unsubscribe(f, sub)
 
 	for {
 		select {
-		case err := <-sub.Err():
-			return status.Errorf(codes.Internal, "subscription error: %v", err)
-		case header := <-headers:
-			blockData, err := s.fetchBlockData(ctx, header.Number)
-			if err != nil {
-				return status.Errorf(codes.Internal, "failed to fetch block data: %v", err)
+		case event, ok := <-sub.events:
+			if !ok {
+				return sub.err
 			}
 
-			if err := stream.Send(blockData); err != nil {
-				return status.Errorf(codes.Internal, "failed to send block data: %v", err)
+			if err := bs.apply(ctx, event); err != nil {
+				return err
 			}
 		case <-ctx.Done():
 			return ctx.Err()
@@ -95,59 +155,118 @@ func (s *EthereumService) SubscribeNewBlocks(req *pb.SubscribeNewBlocksRequest,
 	}
 }
 
+// rangeConcurrency is the number of fetches GetBlockRange runs in parallel.
+// A fetch covers a single block, or a whole batch when batching is enabled.
+const rangeConcurrency = 10
+
+type rangeResult struct {
+	num       int64
+	blockData *pb.BlockData
+	err       error
+}
+
+func (r rangeResult) event() *pb.BlockEvent {
+	if r.err != nil {
+		return blockErrorEvent(r.num, r.err)
+	}
+
+	return &pb.BlockEvent{
+		Event: &pb.BlockEvent_BlockData{BlockData: r.blockData},
+	}
+}
+
 func (s *EthereumService) GetBlockRange(req *pb.GetBlockRangeRequest, stream pb.EthereumService_GetBlockRangeServer) error {
-	sem := make(chan struct{}, 10)
-	var wg sync.WaitGroup
-	var errCh = make(chan error, 1)
+	if req.StartBlock < 0 || req.EndBlock < req.StartBlock {
+		return status.Errorf(codes.InvalidArgument, "invalid block range: %d-%d", req.StartBlock, req.EndBlock)
+	}
+
+	// Cancelled on the first failed block or when the client goes away, which
+	// stops scheduling and aborts the fetches still in flight.
+	ctx, cancel := context.WithCancel(stream.Context())
+	defer cancel()
 
-	for blockNum := req.StartBlock; blockNum <= req.EndBlock; blockNum++ {
-		sem <- struct{}{}
-		wg.Add(1)
+	quorum := req.Quorum || s.quorumReads
 
-		go func(num int64) {
-			defer func() { <-sem; wg.Done() }()
+	batchSize := max(s.batchSize, 1)
+	window := rangeConcurrency * batchSize
 
-			blockData, err := s.fetchBlockData(stream.Context(), big.NewInt(num))
-			if err != nil {
+	// A slot is held from the moment a block's fetch starts until it is
+	// sent, so the reorder buffer never exceeds window blocks.
+	sem := make(chan struct{}, window)
+	results := make(chan rangeResult, window)
+
+	go func() {
+		for from := req.StartBlock; from <= req.EndBlock; from += int64(batchSize) {
+			to := min(from+int64(batchSize)-1, req.EndBlock)
+			for num := from; num <= to; num++ {
 				select {
-				case errCh <- err:
-				default:
+				case sem <- struct{}{}:
+				case <-ctx.Done():
+					return
 				}
-				return
 			}
 
-			if err := stream.Send(blockData); err != nil {
-				select {
-				case errCh <- err:
-				default:
+			go func(from, to int64) {
+				for _, r := range s.fetchBlockRange(ctx, from, to, quorum) {
+					results <- r
 				}
+			}(from, to)
+		}
+	}()
+
+	pending := make(map[int64]rangeResult, window)
+	for next := req.StartBlock; next <= req.EndBlock; {
+		select {
+		case r := <-results:
+			if r.err != nil && (!req.ReportErrors || ctx.Err() != nil) {
+				return rangeError(ctx, r)
 			}
-		}(blockNum)
+			pending[r.num] = r
+		case <-ctx.Done():
+			return status.FromContextError(ctx.Err()).Err()
+		}
+
+		for r, ok := pending[next]; ok; r, ok = pending[next] {
+			delete(pending, next)
+			if err := stream.Send(r.event()); err != nil {
+				return status.Errorf(codes.Internal, "failed to send block event: %v", err)
+			}
+
+			<-sem
+			next++
+		}
 	}
 
-	wg.Wait()
-	close(sem)
+	return nil
+}
 
-	select {
-	case err := <-errCh:
-		return status.Error(codes.Internal, err.Error())
-	default:
-		return nil
+// rangeError converts the failure of a single block into the status that
+// ends the range stream.
+func rangeError(ctx context.Context, r rangeResult) error {
+	// The fetch was aborted because the client went away, not because the
+	// block is bad.
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return status.FromContextError(ctxErr).Err()
 	}
+
+	return status.Errorf(codes.Internal, "failed to fetch block %d: %v", r.num, r.err)
 }
 
-func (s *EthereumService) fetchLatestBlock(ctx context.Context) (*pb.LatestBlock, error) {
-	client, err := s.client.CurrentClient()
+func (s *EthereumService) fetchLatestBlock(ctx context.Context, confirmation pb.BlockConfirmation, confirmations uint32) (*pb.LatestBlock, error) {
+	header, err := s.headerByNumber(ctx, nil)
 	if err != nil {
 		return nil, err
 	}
 
-	header, err := client.HeaderByNumber(ctx, nil)
-
+	header, err = s.confirmedHeader(ctx, header, confirmation, confirmations)
 	if err != nil {
 		return nil, err
 	}
 
+	if header == nil {
+		return nil, fmt.Errorf("chain is shorter than %d confirmations", confirmations)
+	}
+
 	pbLatestBlock := &pb.LatestBlock{
 		BlockNumber: header.Number.Int64(),
 		Hash:        header.Hash().Hex(),
@@ -156,24 +275,156 @@ func (s *EthereumService) fetchLatestBlock(ctx context.Context) (*pb.LatestBlock
 	return pbLatestBlock, nil
 }
 
-func (s *EthereumService) fetchBlockData(ctx context.Context, blockNum *big.Int) (*pb.BlockData, error) {
-	client, err := s.client.CurrentClient()
-	if err != nil {
+// validateConfirmation rejects confirmation modes this service does not
+// know, such as values added to the enum by newer clients.
+func validateConfirmation(confirmation pb.BlockConfirmation) error {
+	switch confirmation {
+	case pb.BlockConfirmation_BLOCK_CONFIRMATION_LATEST,
+		pb.BlockConfirmation_BLOCK_CONFIRMATION_SAFE,
+		pb.BlockConfirmation_BLOCK_CONFIRMATION_FINALIZED:
+		return nil
+	}
+	return status.Errorf(codes.InvalidArgument, "unknown block confirmation: %v", confirmation)
+}
+
+// confirmedHeader returns the header that satisfies the confirmation mode
+// given the current tip. It returns nil if no block is confirmed yet.
+func (s *EthereumService) confirmedHeader(ctx context.Context, tip *types.Header, confirmation pb.BlockConfirmation, confirmations uint32) (*types.Header, error) {
+	var number *big.Int
+
+	switch confirmation {
+	case pb.BlockConfirmation_BLOCK_CONFIRMATION_SAFE:
+		number = big.NewInt(int64(gethrpc.SafeBlockNumber))
+		ctx = rpc.WithBlockTag(ctx, rpc.BlockTagSafe)
+	case pb.BlockConfirmation_BLOCK_CONFIRMATION_FINALIZED:
+		number = big.NewInt(int64(gethrpc.FinalizedBlockNumber))
+		ctx = rpc.WithBlockTag(ctx, rpc.BlockTagFinalized)
+	case pb.BlockConfirmation_BLOCK_CONFIRMATION_LATEST:
+		if confirmations == 0 {
+			return tip, nil
+		}
+
+		num := tip.Number.Int64() - int64(confirmations)
+		if num < 0 {
+			return nil, nil
+		}
+		number = big.NewInt(num)
+	default:
+		return nil, fmt.Errorf("unknown block confirmation: %v", confirmation)
+	}
+
+	return s.headerByNumber(ctx, number)
+}
+
+func (s *EthereumService) headerByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	return rpc.Call(ctx, s.client, "eth_getBlockByNumber", func(ctx context.Context, client *ethclient.Client) (*types.Header, error) {
+		return client.HeaderByNumber(ctx, number)
+	})
+}
+
+// CacheStatus reports the size and hit rate of the block cache.
+func (s *EthereumService) CacheStatus() map[string]interface{} {
+	return s.cache.status()
+}
+
+// fetchBlockData returns a block with its receipts from the cache, or
+// fetches it and caches it if it is settled.
+func (s *EthereumService) fetchBlockData(ctx context.Context, blockNum *big.Int, quorum bool) (*pb.BlockData, error) {
+	// Cached blocks may not have been agreed on by a quorum.
+	if !quorum {
+		if blockData, ok := s.cache.get(blockNum.Int64()); ok {
+			return blockData, nil
+		}
+	}
+
+	blockData, err := s.fetchBlockDataUncached(ctx, blockNum, quorum)
+	if err == nil {
+		s.cache.add(blockData)
+	}
+	return blockData, err
+}
+
+// fetchBlockDataUncached fetches a block with its receipts, both from the
+// same endpoint so they come from the same view of the chain. With quorum
+// set the block must be agreed on by a quorum of endpoints.
+func (s *EthereumService) fetchBlockDataUncached(ctx context.Context, blockNum *big.Int, quorum bool) (*pb.BlockData, error) {
+	if quorum {
+		return s.fetchBlockDataQuorum(ctx, blockNum)
+	}
+
+	arg := hexutil.EncodeBig(blockNum)
+	blockData, err := rpc.Session(ctx, s.client, []string{"eth_getBlockByNumber", "eth_getBlockReceipts"}, func(ctx context.Context, client *ethclient.Client) (*pb.BlockData, error) {
+		return s.fetchBlockDataFrom(ctx, client, arg, false)
+	})
+	if !rpc.IsMethodNotFound(err) {
+		return blockData, err
+	}
+
+	// No endpoint provides eth_getBlockReceipts.
+	return rpc.Session(ctx, s.client, []string{"eth_getBlockByNumber", "eth_getTransactionReceipt"}, func(ctx context.Context, client *ethclient.Client) (*pb.BlockData, error) {
+		return s.fetchBlockDataFrom(ctx, client, arg, true)
+	})
+}
+
+// fetchBlockDataFrom fetches the block numbered arg and its receipts from a
+// single endpoint, one transaction at a time with byTx set or when the
+// endpoint turns out not to provide eth_getBlockReceipts.
+func (s *EthereumService) fetchBlockDataFrom(ctx context.Context, client *ethclient.Client, arg string, byTx bool) (*pb.BlockData, error) {
+	var raw json.RawMessage
+	if err := rpc.CallOn(ctx, client, &raw, "eth_getBlockByNumber", arg, true); err != nil {
 		return nil, err
 	}
 
-	block, err := client.BlockByNumber(ctx, blockNum)
+	block, err := decodeBlock(raw, s.verifyBlocks)
 	if err != nil {
 		return nil, err
 	}
 
-	receipts, err := s.getBlockReceipts(ctx, block.Hash())
+	var receipts []*types.Receipt
+	if !byTx {
+		err = rpc.CallOn(ctx, client, &receipts, "eth_getBlockReceipts", block.Hash().Hex())
+		if err == nil && s.verifyBlocks {
+			err = verifyReceipts(block, receipts)
+		}
+	}
+	if byTx || rpc.IsMethodNotFound(err) {
+		receipts, err = s.pinned(client).receiptsByTx(ctx, block)
+	}
 	if err != nil {
-		return nil, fmt.Errorf("failed to get block receipts: %v", err)
+		return nil, fmt.Errorf("failed to get block receipts: %w", err)
 	}
 
+	return s.buildBlockData(block, receipts)
+}
+
+// fetchBlockByHash fetches the block with full transactions whose header
+// hashes to hash. Its body is always checked against the header, and a
+// block that does not match is fetched again from another endpoint, so
+// the answer of a single endpoint cannot replace a block agreed on by a
+// quorum.
+func (s *EthereumService) fetchBlockByHash(ctx context.Context, hash common.Hash) (*types.Block, error) {
+	return rpc.Call(ctx, s.client, "eth_getBlockByHash", func(ctx context.Context, client *ethclient.Client) (*types.Block, error) {
+		var raw json.RawMessage
+		if err := client.Client().CallContext(ctx, &raw, "eth_getBlockByHash", hash.Hex(), true); err != nil {
+			return nil, err
+		}
+
+		block, err := decodeBlock(raw, true)
+		if err != nil {
+			return nil, err
+		}
+
+		if block.Hash() != hash {
+			return nil, fmt.Errorf("%w: block %d hashes to %s, requested %s", rpc.ErrBadResponse, block.NumberU64(), block.Hash().Hex(), hash.Hex())
+		}
+		return block, nil
+	})
+}
+
+// buildBlockData converts a block and its receipts into a BlockData.
+func (s *EthereumService) buildBlockData(block *types.Block, receipts []*types.Receipt) (*pb.BlockData, error) {
 	if len(receipts) != len(block.Transactions()) {
-		return nil, fmt.Errorf("receipts count mismatch")
+		return nil, errReceiptsMismatch
 	}
 
 	pbBlock := convertBlockToPB(block)
@@ -202,28 +453,6 @@ func (s *EthereumService) fetchBlockData(ctx context.Context, blockNum *big.Int)
 	}, nil
 }
 
-func (s *EthereumService) getBlockReceipts(ctx context.Context, blockHash common.Hash) ([]*types.Receipt, error) {
-	var receipts []*types.Receipt
-	client, err := s.client.CurrentClient()
-
-	if err != nil {
-		return nil, err
-	}
-
-	client.Client().CallContext(
-		ctx,
-		&receipts,
-		"eth_getBlockReceipts",
-		blockHash.Hex(),
-	)
-
-	if err != nil {
-		return nil, err
-	}
-
-	return receipts, nil
-}
-
 func (s *EthereumService) getFromAddress(tx *types.Transaction) string {
 	from, err := types.Sender(s.signer, tx)
 	if err != nil {
@@ -0,0 +1,278 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+	"math/big"
+	"time"
+
+	"github.com/al002/sylph/chains/ethereum/pkg/pb"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/core/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// The endpoint serving a block can be a little behind the one that
+// announced it as the head. A head block that is not found yet is fetched
+// again after headRetryDelay, up to maxHeadRetries times before it is
+// reported as missing.
+const (
+	headRetryDelay = 250 * time.Millisecond
+	maxHeadRetries = 4
+)
+
+// blockStream delivers blocks in order, without gaps, and rolls back blocks
+// orphaned by a reorg. It backs both the shared head follower and each
+// SubscribeNewBlocks call.
+type blockStream struct {
+	s      *EthereumService
+	send   func(*pb.BlockEvent) error
+	window *blockWindow
+
+	// canonicalHash looks up the canonical hash at a height while rolling
+	// back, fetchCanonicalHash of s outside of tests.
+	canonicalHash func(ctx context.Context, num int64) (string, error)
+
+	// reportErrors sends a BlockError for blocks that cannot be fetched
+	// instead of ending the stream.
+	reportErrors bool
+
+	// next is the number of the next block to send, 0 until it is known.
+	next int64
+}
+
+func newBlockStream(s *EthereumService, send func(*pb.BlockEvent) error, start int64, reportErrors bool) *blockStream {
+	return &blockStream{
+		s:             s,
+		send:          send,
+		window:        newBlockWindow(reorgWindowSize),
+		canonicalHash: s.fetchCanonicalHash,
+		reportErrors:  reportErrors,
+		next:          start,
+	}
+}
+
+// handleHeader sends everything up to and including the new head.
+func (bs *blockStream) handleHeader(ctx context.Context, header *types.Header) error {
+	return bs.handleHead(ctx, header.Number.Int64(), header.Hash().Hex())
+}
+
+func (bs *blockStream) handleHead(ctx context.Context, num int64, hash string) error {
+	if bs.next == 0 {
+		bs.next = num
+	}
+
+	if num < bs.next {
+		// Either a head we already sent, or a reorg onto a chain that is
+		// not longer than the one we followed.
+		if sent, ok := bs.window.get(num); !ok || sent.Hash == hash {
+			return nil
+		}
+
+		if err := bs.rollback(ctx, bs.next-1); err != nil {
+			return err
+		}
+	}
+
+	// Sending from next rather than num fills any heads the subscription
+	// skipped.
+	return bs.advance(ctx, num)
+}
+
+// advance sends the blocks from next up to and including target in ascending
+// order, rolling back first whenever a block does not link to its parent.
+// A target block not found yet is retried a few times before it counts as
+// missing.
+func (bs *blockStream) advance(ctx context.Context, target int64) error {
+	retries, headRetries := 0, 0
+	for bs.next <= target {
+		blockData, err := bs.s.fetchBlockData(ctx, big.NewInt(bs.next), bs.s.quorumReads)
+		if err != nil {
+			if bs.next == target && errors.Is(err, ethereum.NotFound) && headRetries < maxHeadRetries && ctx.Err() == nil {
+				headRetries++
+				select {
+				case <-time.After(headRetryDelay):
+				case <-ctx.Done():
+					return ctx.Err()
+				}
+				continue
+			}
+
+			if !bs.reportErrors || ctx.Err() != nil {
+				return status.Errorf(codes.Internal, "failed to fetch block data for block %d: %v", bs.next, err)
+			}
+
+			if err := bs.send(blockErrorEvent(bs.next, err)); err != nil {
+				return err
+			}
+			bs.next++
+			continue
+		}
+
+		parent, ok := bs.window.get(bs.next - 1)
+		if ok && parent.Hash != blockData.Block.ParentHash {
+			next := bs.next
+			if err := bs.rollback(ctx, bs.next-1); err != nil {
+				return err
+			}
+
+			// Nothing was rolled back: the parent is still reported
+			// canonical, so give the endpoints time to agree.
+			if bs.next == next {
+				if retries++; retries > maxReorgRetries {
+					return status.Errorf(codes.Unavailable, "block %d does not link to canonical parent %s", next, parent.Hash)
+				}
+
+				select {
+				case <-time.After(reorgRetryDelay):
+				case <-ctx.Done():
+					return ctx.Err()
+				}
+			}
+			continue
+		}
+
+		if err := bs.send(&pb.BlockEvent{
+			Event: &pb.BlockEvent_BlockData{BlockData: blockData},
+		}); err != nil {
+			return err
+		}
+
+		bs.window.add(blockData.Block)
+		bs.next++
+		retries = 0
+	}
+
+	return nil
+}
+
+// rollback walks back from num until it finds a sent block that is still
+// canonical, sends a removal for every sent block above it and rewinds next
+// so the canonical replacements are sent by the following advance.
+func (bs *blockStream) rollback(ctx context.Context, num int64) error {
+	for ; ; num-- {
+		sent, ok := bs.window.get(num)
+		if !ok {
+			log.Printf("Reorg deeper than %d blocks, resuming from block %d", reorgWindowSize, num+1)
+			break
+		}
+
+		hash, err := bs.canonicalHash(ctx, num)
+		if err != nil {
+			return status.Errorf(codes.Internal, "failed to fetch header for block %d: %v", num, err)
+		}
+
+		if hash == sent.Hash {
+			break
+		}
+	}
+
+	for _, block := range bs.window.removeAbove(num) {
+		if err := bs.send(&pb.BlockEvent{
+			Event: &pb.BlockEvent_BlockRemoved{BlockRemoved: &pb.BlockRemoved{
+				BlockNumber: block.BlockNumber,
+				Hash:        block.Hash,
+				ParentHash:  block.ParentHash,
+			}},
+		}); err != nil {
+			return err
+		}
+	}
+
+	bs.next = num + 1
+	return nil
+}
+
+// apply takes an event of the shared follower. Events that line up with
+// what was sent so far are passed on as they are; otherwise the stream
+// catches up by fetching blocks itself, which only happens while joining
+// the follower, after dropped events or after the follower restarted.
+func (bs *blockStream) apply(ctx context.Context, event *pb.BlockEvent) error {
+	switch ev := event.Event.(type) {
+	case *pb.BlockEvent_BlockData:
+		block := ev.BlockData.Block
+		num := block.BlockNumber
+		if bs.next == 0 {
+			bs.next = num
+		}
+
+		if num == bs.next {
+			if parent, ok := bs.window.get(num - 1); !ok || parent.Hash == block.ParentHash {
+				if err := bs.send(event); err != nil {
+					return err
+				}
+
+				bs.window.add(block)
+				bs.next++
+				return nil
+			}
+		}
+
+		return bs.handleHead(ctx, num, block.Hash)
+
+	case *pb.BlockEvent_BlockRemoved:
+		removed := ev.BlockRemoved
+		if sent, ok := bs.window.get(removed.BlockNumber); !ok || sent.Hash != removed.Hash {
+			return nil
+		}
+
+		for _, block := range bs.window.removeAbove(removed.BlockNumber - 1) {
+			if err := bs.send(&pb.BlockEvent{
+				Event: &pb.BlockEvent_BlockRemoved{BlockRemoved: &pb.BlockRemoved{
+					BlockNumber: block.BlockNumber,
+					Hash:        block.Hash,
+					ParentHash:  block.ParentHash,
+				}},
+			}); err != nil {
+				return err
+			}
+		}
+
+		bs.next = removed.BlockNumber
+		return nil
+
+	case *pb.BlockEvent_BlockError:
+		num := ev.BlockError.BlockNumber
+		if bs.next == 0 {
+			bs.next = num
+		}
+
+		if num < bs.next {
+			return nil
+		}
+
+		if num > bs.next {
+			if err := bs.advance(ctx, num-1); err != nil {
+				return err
+			}
+		}
+
+		if !bs.reportErrors {
+			return status.Errorf(codes.Internal, "failed to fetch block data for block %d: %s", num, ev.BlockError.Reason)
+		}
+
+		if err := bs.send(event); err != nil {
+			return err
+		}
+		bs.next++
+		return nil
+	}
+
+	return nil
+}
+
+// fetchCanonicalHash returns the hash of the canonical block at num, or an
+// empty string if the chain is currently shorter than num.
+func (s *EthereumService) fetchCanonicalHash(ctx context.Context, num int64) (string, error) {
+	header, err := s.headerByNumber(ctx, big.NewInt(num))
+	if errors.Is(err, ethereum.NotFound) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+
+	return header.Hash().Hex(), nil
+}
@@ -0,0 +1,52 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/al002/sylph/chains/ethereum/pkg/rpc"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/trie"
+)
+
+// verifyBody checks that the header of block hashes to the hash the node
+// reported and that its transactions and withdrawals match the roots in the
+// header. Failures wrap rpc.ErrBadResponse, so the block is fetched again
+// from another endpoint.
+func verifyBody(block *types.Block, reportedHash common.Hash) error {
+	if hash := block.Hash(); hash != reportedHash {
+		return fmt.Errorf("%w: block %d header hashes to %s, node reported %s", rpc.ErrBadResponse, block.NumberU64(), hash.Hex(), reportedHash.Hex())
+	}
+
+	if root := types.DeriveSha(block.Transactions(), trie.NewStackTrie(nil)); root != block.TxHash() {
+		return fmt.Errorf("%w: block %d transactions root is %s, header has %s", rpc.ErrBadResponse, block.NumberU64(), root.Hex(), block.TxHash().Hex())
+	}
+
+	if want := block.Header().WithdrawalsHash; want != nil {
+		if root := types.DeriveSha(block.Withdrawals(), trie.NewStackTrie(nil)); root != *want {
+			return fmt.Errorf("%w: block %d withdrawals root is %s, header has %s", rpc.ErrBadResponse, block.NumberU64(), root.Hex(), want.Hex())
+		}
+	}
+
+	return nil
+}
+
+// verifyReceipts checks that every receipt belongs to block and that the
+// receipts match the receipts root in its header.
+func verifyReceipts(block *types.Block, receipts []*types.Receipt) error {
+	if len(receipts) != len(block.Transactions()) {
+		return fmt.Errorf("%w: %w: block %d has %d transactions, got %d receipts", rpc.ErrBadResponse, errReceiptsMismatch, block.NumberU64(), len(block.Transactions()), len(receipts))
+	}
+
+	for i, receipt := range receipts {
+		if receipt.BlockHash != block.Hash() {
+			return fmt.Errorf("%w: receipt %d of block %d belongs to block %s", rpc.ErrBadResponse, i, block.NumberU64(), receipt.BlockHash.Hex())
+		}
+	}
+
+	if root := types.DeriveSha(types.Receipts(receipts), trie.NewStackTrie(nil)); root != block.ReceiptHash() {
+		return fmt.Errorf("%w: block %d receipts root is %s, header has %s", rpc.ErrBadResponse, block.NumberU64(), root.Hex(), block.ReceiptHash().Hex())
+	}
+
+	return nil
+}
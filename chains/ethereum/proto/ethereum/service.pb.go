@@ -9,7 +9,6 @@ package proto
 import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
-	emptypb "google.golang.org/protobuf/types/known/emptypb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -22,6 +21,113 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// BlockConfirmation selects which head of the chain a request follows.
+type BlockConfirmation int32
+
+const (
+	// The tip of the chain, optionally lagged by a number of confirmations.
+	BlockConfirmation_BLOCK_CONFIRMATION_LATEST BlockConfirmation = 0
+	// The execution client's "safe" block.
+	BlockConfirmation_BLOCK_CONFIRMATION_SAFE BlockConfirmation = 1
+	// The execution client's "finalized" block.
+	BlockConfirmation_BLOCK_CONFIRMATION_FINALIZED BlockConfirmation = 2
+)
+
+// Enum value maps for BlockConfirmation.
+var (
+	BlockConfirmation_name = map[int32]string{
+		0: "BLOCK_CONFIRMATION_LATEST",
+		1: "BLOCK_CONFIRMATION_SAFE",
+		2: "BLOCK_CONFIRMATION_FINALIZED",
+	}
+	BlockConfirmation_value = map[string]int32{
+		"BLOCK_CONFIRMATION_LATEST":    0,
+		"BLOCK_CONFIRMATION_SAFE":      1,
+		"BLOCK_CONFIRMATION_FINALIZED": 2,
+	}
+)
+
+func (x BlockConfirmation) Enum() *BlockConfirmation {
+	p := new(BlockConfirmation)
+	*p = x
+	return p
+}
+
+func (x BlockConfirmation) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (BlockConfirmation) Descriptor() protoreflect.EnumDescriptor {
+	return file_ethereum_service_proto_enumTypes[0].Descriptor()
+}
+
+func (BlockConfirmation) Type() protoreflect.EnumType {
+	return &file_ethereum_service_proto_enumTypes[0]
+}
+
+func (x BlockConfirmation) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use BlockConfirmation.Descriptor instead.
+func (BlockConfirmation) EnumDescriptor() ([]byte, []int) {
+	return file_ethereum_service_proto_rawDescGZIP(), []int{0}
+}
+
+type GetLatestBlockRequest struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	Confirmation BlockConfirmation      `protobuf:"varint,1,opt,name=confirmation,proto3,enum=ethereum.BlockConfirmation" json:"confirmation,omitempty"`
+	// Number of blocks the tip must be ahead of the returned block. Only used
+	// with BLOCK_CONFIRMATION_LATEST.
+	Confirmations uint32 `protobuf:"varint,2,opt,name=confirmations,proto3" json:"confirmations,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetLatestBlockRequest) Reset() {
+	*x = GetLatestBlockRequest{}
+	mi := &file_ethereum_service_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetLatestBlockRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLatestBlockRequest) ProtoMessage() {}
+
+func (x *GetLatestBlockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ethereum_service_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLatestBlockRequest.ProtoReflect.Descriptor instead.
+func (*GetLatestBlockRequest) Descriptor() ([]byte, []int) {
+	return file_ethereum_service_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *GetLatestBlockRequest) GetConfirmation() BlockConfirmation {
+	if x != nil {
+		return x.Confirmation
+	}
+	return BlockConfirmation_BLOCK_CONFIRMATION_LATEST
+}
+
+func (x *GetLatestBlockRequest) GetConfirmations() uint32 {
+	if x != nil {
+		return x.Confirmations
+	}
+	return 0
+}
+
 type GetLatestBlockResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	LatestBlock   *LatestBlock           `protobuf:"bytes,1,opt,name=latest_block,json=latestBlock,proto3" json:"latest_block,omitempty"`
@@ -31,7 +137,7 @@ type GetLatestBlockResponse struct {
 
 func (x *GetLatestBlockResponse) Reset() {
 	*x = GetLatestBlockResponse{}
-	mi := &file_ethereum_service_proto_msgTypes[0]
+	mi := &file_ethereum_service_proto_msgTypes[1]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -43,7 +149,7 @@ func (x *GetLatestBlockResponse) String() string {
 func (*GetLatestBlockResponse) ProtoMessage() {}
 
 func (x *GetLatestBlockResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_ethereum_service_proto_msgTypes[0]
+	mi := &file_ethereum_service_proto_msgTypes[1]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -56,7 +162,7 @@ func (x *GetLatestBlockResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetLatestBlockResponse.ProtoReflect.Descriptor instead.
 func (*GetLatestBlockResponse) Descriptor() ([]byte, []int) {
-	return file_ethereum_service_proto_rawDescGZIP(), []int{0}
+	return file_ethereum_service_proto_rawDescGZIP(), []int{1}
 }
 
 func (x *GetLatestBlockResponse) GetLatestBlock() *LatestBlock {
@@ -67,15 +173,17 @@ func (x *GetLatestBlockResponse) GetLatestBlock() *LatestBlock {
 }
 
 type GetBlockRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	BlockNumber   int64                  `protobuf:"varint,1,opt,name=block_number,json=blockNumber,proto3" json:"block_number,omitempty"`
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	BlockNumber int64                  `protobuf:"varint,1,opt,name=block_number,json=blockNumber,proto3" json:"block_number,omitempty"`
+	// Only accept the block once a quorum of endpoints agrees on it.
+	Quorum        bool `protobuf:"varint,2,opt,name=quorum,proto3" json:"quorum,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *GetBlockRequest) Reset() {
 	*x = GetBlockRequest{}
-	mi := &file_ethereum_service_proto_msgTypes[1]
+	mi := &file_ethereum_service_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -87,7 +195,7 @@ func (x *GetBlockRequest) String() string {
 func (*GetBlockRequest) ProtoMessage() {}
 
 func (x *GetBlockRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_ethereum_service_proto_msgTypes[1]
+	mi := &file_ethereum_service_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -100,7 +208,7 @@ func (x *GetBlockRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetBlockRequest.ProtoReflect.Descriptor instead.
 func (*GetBlockRequest) Descriptor() ([]byte, []int) {
-	return file_ethereum_service_proto_rawDescGZIP(), []int{1}
+	return file_ethereum_service_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *GetBlockRequest) GetBlockNumber() int64 {
@@ -110,6 +218,13 @@ func (x *GetBlockRequest) GetBlockNumber() int64 {
 	return 0
 }
 
+func (x *GetBlockRequest) GetQuorum() bool {
+	if x != nil {
+		return x.Quorum
+	}
+	return false
+}
+
 type GetBlockResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	BlockData     *BlockData             `protobuf:"bytes,1,opt,name=block_data,json=blockData,proto3" json:"block_data,omitempty"`
@@ -119,7 +234,7 @@ type GetBlockResponse struct {
 
 func (x *GetBlockResponse) Reset() {
 	*x = GetBlockResponse{}
-	mi := &file_ethereum_service_proto_msgTypes[2]
+	mi := &file_ethereum_service_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -131,7 +246,7 @@ func (x *GetBlockResponse) String() string {
 func (*GetBlockResponse) ProtoMessage() {}
 
 func (x *GetBlockResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_ethereum_service_proto_msgTypes[2]
+	mi := &file_ethereum_service_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -144,7 +259,7 @@ func (x *GetBlockResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetBlockResponse.ProtoReflect.Descriptor instead.
 func (*GetBlockResponse) Descriptor() ([]byte, []int) {
-	return file_ethereum_service_proto_rawDescGZIP(), []int{2}
+	return file_ethereum_service_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *GetBlockResponse) GetBlockData() *BlockData {
@@ -156,15 +271,25 @@ func (x *GetBlockResponse) GetBlockData() *BlockData {
 
 type SubscribeNewBlocksRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// Optional starting block number
-	StartBlock    int64 `protobuf:"varint,1,opt,name=start_block,json=startBlock,proto3" json:"start_block,omitempty"`
+	// Optional starting block number. When set, blocks from start_block up to
+	// the current head are streamed first, followed by live blocks.
+	StartBlock int64 `protobuf:"varint,1,opt,name=start_block,json=startBlock,proto3" json:"start_block,omitempty"`
+	// Only blocks at or below the selected head are streamed, so SAFE and
+	// FINALIZED streams are not expected to see reorgs.
+	Confirmation BlockConfirmation `protobuf:"varint,2,opt,name=confirmation,proto3,enum=ethereum.BlockConfirmation" json:"confirmation,omitempty"`
+	// Number of blocks the tip must be ahead of a streamed block. Only used
+	// with BLOCK_CONFIRMATION_LATEST.
+	Confirmations uint32 `protobuf:"varint,3,opt,name=confirmations,proto3" json:"confirmations,omitempty"`
+	// Send a block_error for blocks that cannot be fetched and carry on,
+	// instead of ending the stream with an error.
+	ReportErrors  bool `protobuf:"varint,4,opt,name=report_errors,json=reportErrors,proto3" json:"report_errors,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *SubscribeNewBlocksRequest) Reset() {
 	*x = SubscribeNewBlocksRequest{}
-	mi := &file_ethereum_service_proto_msgTypes[3]
+	mi := &file_ethereum_service_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -176,7 +301,7 @@ func (x *SubscribeNewBlocksRequest) String() string {
 func (*SubscribeNewBlocksRequest) ProtoMessage() {}
 
 func (x *SubscribeNewBlocksRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_ethereum_service_proto_msgTypes[3]
+	mi := &file_ethereum_service_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -189,7 +314,7 @@ func (x *SubscribeNewBlocksRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SubscribeNewBlocksRequest.ProtoReflect.Descriptor instead.
 func (*SubscribeNewBlocksRequest) Descriptor() ([]byte, []int) {
-	return file_ethereum_service_proto_rawDescGZIP(), []int{3}
+	return file_ethereum_service_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *SubscribeNewBlocksRequest) GetStartBlock() int64 {
@@ -199,17 +324,43 @@ func (x *SubscribeNewBlocksRequest) GetStartBlock() int64 {
 	return 0
 }
 
+func (x *SubscribeNewBlocksRequest) GetConfirmation() BlockConfirmation {
+	if x != nil {
+		return x.Confirmation
+	}
+	return BlockConfirmation_BLOCK_CONFIRMATION_LATEST
+}
+
+func (x *SubscribeNewBlocksRequest) GetConfirmations() uint32 {
+	if x != nil {
+		return x.Confirmations
+	}
+	return 0
+}
+
+func (x *SubscribeNewBlocksRequest) GetReportErrors() bool {
+	if x != nil {
+		return x.ReportErrors
+	}
+	return false
+}
+
 type GetBlockRangeRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	StartBlock    int64                  `protobuf:"varint,1,opt,name=start_block,json=startBlock,proto3" json:"start_block,omitempty"`
-	EndBlock      int64                  `protobuf:"varint,2,opt,name=end_block,json=endBlock,proto3" json:"end_block,omitempty"`
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	StartBlock int64                  `protobuf:"varint,1,opt,name=start_block,json=startBlock,proto3" json:"start_block,omitempty"`
+	EndBlock   int64                  `protobuf:"varint,2,opt,name=end_block,json=endBlock,proto3" json:"end_block,omitempty"`
+	// Send a block_error for blocks that cannot be fetched and carry on,
+	// instead of ending the stream with an error.
+	ReportErrors bool `protobuf:"varint,3,opt,name=report_errors,json=reportErrors,proto3" json:"report_errors,omitempty"`
+	// Only accept each block once a quorum of endpoints agrees on it.
+	Quorum        bool `protobuf:"varint,4,opt,name=quorum,proto3" json:"quorum,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *GetBlockRangeRequest) Reset() {
 	*x = GetBlockRangeRequest{}
-	mi := &file_ethereum_service_proto_msgTypes[4]
+	mi := &file_ethereum_service_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -221,7 +372,7 @@ func (x *GetBlockRangeRequest) String() string {
 func (*GetBlockRangeRequest) ProtoMessage() {}
 
 func (x *GetBlockRangeRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_ethereum_service_proto_msgTypes[4]
+	mi := &file_ethereum_service_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -234,7 +385,7 @@ func (x *GetBlockRangeRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetBlockRangeRequest.ProtoReflect.Descriptor instead.
 func (*GetBlockRangeRequest) Descriptor() ([]byte, []int) {
-	return file_ethereum_service_proto_rawDescGZIP(), []int{4}
+	return file_ethereum_service_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *GetBlockRangeRequest) GetStartBlock() int64 {
@@ -251,61 +402,103 @@ func (x *GetBlockRangeRequest) GetEndBlock() int64 {
 	return 0
 }
 
+func (x *GetBlockRangeRequest) GetReportErrors() bool {
+	if x != nil {
+		return x.ReportErrors
+	}
+	return false
+}
+
+func (x *GetBlockRangeRequest) GetQuorum() bool {
+	if x != nil {
+		return x.Quorum
+	}
+	return false
+}
+
 var File_ethereum_service_proto protoreflect.FileDescriptor
 
 var file_ethereum_service_proto_rawDesc = string([]byte{
 	0x0a, 0x16, 0x65, 0x74, 0x68, 0x65, 0x72, 0x65, 0x75, 0x6d, 0x2f, 0x73, 0x65, 0x72, 0x76, 0x69,
 	0x63, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x08, 0x65, 0x74, 0x68, 0x65, 0x72, 0x65,
-	0x75, 0x6d, 0x1a, 0x1b, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x62, 0x75, 0x66, 0x2f, 0x65, 0x6d, 0x70, 0x74, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a,
-	0x14, 0x65, 0x74, 0x68, 0x65, 0x72, 0x65, 0x75, 0x6d, 0x2f, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x52, 0x0a, 0x16, 0x47, 0x65, 0x74, 0x4c, 0x61, 0x74, 0x65,
-	0x73, 0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
-	0x38, 0x0a, 0x0c, 0x6c, 0x61, 0x74, 0x65, 0x73, 0x74, 0x5f, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x65, 0x74, 0x68, 0x65, 0x72, 0x65, 0x75, 0x6d,
-	0x2e, 0x4c, 0x61, 0x74, 0x65, 0x73, 0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x52, 0x0b, 0x6c, 0x61,
-	0x74, 0x65, 0x73, 0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x22, 0x34, 0x0a, 0x0f, 0x47, 0x65, 0x74,
-	0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x21, 0x0a, 0x0c,
-	0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x03, 0x52, 0x0b, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x22,
-	0x46, 0x0a, 0x10, 0x47, 0x65, 0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x12, 0x32, 0x0a, 0x0a, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x64, 0x61, 0x74,
-	0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x65, 0x74, 0x68, 0x65, 0x72, 0x65,
-	0x75, 0x6d, 0x2e, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x44, 0x61, 0x74, 0x61, 0x52, 0x09, 0x62, 0x6c,
-	0x6f, 0x63, 0x6b, 0x44, 0x61, 0x74, 0x61, 0x22, 0x3c, 0x0a, 0x19, 0x53, 0x75, 0x62, 0x73, 0x63,
-	0x72, 0x69, 0x62, 0x65, 0x4e, 0x65, 0x77, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x74, 0x61, 0x72, 0x74, 0x5f, 0x62, 0x6c,
-	0x6f, 0x63, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x73, 0x74, 0x61, 0x72, 0x74,
-	0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x22, 0x54, 0x0a, 0x14, 0x47, 0x65, 0x74, 0x42, 0x6c, 0x6f, 0x63,
-	0x6b, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1f, 0x0a,
-	0x0b, 0x73, 0x74, 0x61, 0x72, 0x74, 0x5f, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x03, 0x52, 0x0a, 0x73, 0x74, 0x61, 0x72, 0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x1b,
-	0x0a, 0x09, 0x65, 0x6e, 0x64, 0x5f, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x03, 0x52, 0x08, 0x65, 0x6e, 0x64, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x32, 0xc2, 0x02, 0x0a, 0x0f,
-	0x45, 0x74, 0x68, 0x65, 0x72, 0x65, 0x75, 0x6d, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12,
-	0x4c, 0x0a, 0x0e, 0x47, 0x65, 0x74, 0x4c, 0x61, 0x74, 0x65, 0x73, 0x74, 0x42, 0x6c, 0x6f, 0x63,
-	0x6b, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x20, 0x2e, 0x65, 0x74, 0x68, 0x65,
-	0x72, 0x65, 0x75, 0x6d, 0x2e, 0x47, 0x65, 0x74, 0x4c, 0x61, 0x74, 0x65, 0x73, 0x74, 0x42, 0x6c,
-	0x6f, 0x63, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x43, 0x0a,
-	0x08, 0x47, 0x65, 0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x19, 0x2e, 0x65, 0x74, 0x68, 0x65,
-	0x72, 0x65, 0x75, 0x6d, 0x2e, 0x47, 0x65, 0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x65, 0x74, 0x68, 0x65, 0x72, 0x65, 0x75, 0x6d, 0x2e,
-	0x47, 0x65, 0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x22, 0x00, 0x12, 0x52, 0x0a, 0x12, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x4e,
-	0x65, 0x77, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x12, 0x23, 0x2e, 0x65, 0x74, 0x68, 0x65, 0x72,
-	0x65, 0x75, 0x6d, 0x2e, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x4e, 0x65, 0x77,
-	0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e,
-	0x65, 0x74, 0x68, 0x65, 0x72, 0x65, 0x75, 0x6d, 0x2e, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x44, 0x61,
-	0x74, 0x61, 0x22, 0x00, 0x30, 0x01, 0x12, 0x48, 0x0a, 0x0d, 0x47, 0x65, 0x74, 0x42, 0x6c, 0x6f,
-	0x63, 0x6b, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x1e, 0x2e, 0x65, 0x74, 0x68, 0x65, 0x72, 0x65,
-	0x75, 0x6d, 0x2e, 0x47, 0x65, 0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x52, 0x61, 0x6e, 0x67, 0x65,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x65, 0x74, 0x68, 0x65, 0x72, 0x65,
-	0x75, 0x6d, 0x2e, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x44, 0x61, 0x74, 0x61, 0x22, 0x00, 0x30, 0x01,
-	0x42, 0x2e, 0x5a, 0x2c, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x61,
-	0x6c, 0x30, 0x30, 0x32, 0x2f, 0x73, 0x79, 0x6c, 0x70, 0x68, 0x2f, 0x63, 0x68, 0x61, 0x69, 0x6e,
-	0x73, 0x2f, 0x65, 0x74, 0x68, 0x65, 0x72, 0x65, 0x75, 0x6d, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x75, 0x6d, 0x1a, 0x14, 0x65, 0x74, 0x68, 0x65, 0x72, 0x65, 0x75, 0x6d, 0x2f, 0x74, 0x79, 0x70,
+	0x65, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x7e, 0x0a, 0x15, 0x47, 0x65, 0x74, 0x4c,
+	0x61, 0x74, 0x65, 0x73, 0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x3f, 0x0a, 0x0c, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x72, 0x6d, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1b, 0x2e, 0x65, 0x74, 0x68, 0x65, 0x72, 0x65,
+	0x75, 0x6d, 0x2e, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x72, 0x6d, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0c, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x72, 0x6d, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x12, 0x24, 0x0a, 0x0d, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x72, 0x6d, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0d, 0x63, 0x6f, 0x6e, 0x66, 0x69,
+	0x72, 0x6d, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0x52, 0x0a, 0x16, 0x47, 0x65, 0x74, 0x4c,
+	0x61, 0x74, 0x65, 0x73, 0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x38, 0x0a, 0x0c, 0x6c, 0x61, 0x74, 0x65, 0x73, 0x74, 0x5f, 0x62, 0x6c, 0x6f,
+	0x63, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x65, 0x74, 0x68, 0x65, 0x72,
+	0x65, 0x75, 0x6d, 0x2e, 0x4c, 0x61, 0x74, 0x65, 0x73, 0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x52,
+	0x0b, 0x6c, 0x61, 0x74, 0x65, 0x73, 0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x22, 0x4c, 0x0a, 0x0f,
+	0x47, 0x65, 0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x21, 0x0a, 0x0c, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x4e, 0x75, 0x6d, 0x62,
+	0x65, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x71, 0x75, 0x6f, 0x72, 0x75, 0x6d, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x06, 0x71, 0x75, 0x6f, 0x72, 0x75, 0x6d, 0x22, 0x46, 0x0a, 0x10, 0x47, 0x65,
+	0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x32,
+	0x0a, 0x0a, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x64, 0x61, 0x74, 0x61, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x13, 0x2e, 0x65, 0x74, 0x68, 0x65, 0x72, 0x65, 0x75, 0x6d, 0x2e, 0x42, 0x6c,
+	0x6f, 0x63, 0x6b, 0x44, 0x61, 0x74, 0x61, 0x52, 0x09, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x44, 0x61,
+	0x74, 0x61, 0x22, 0xc8, 0x01, 0x0a, 0x19, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65,
+	0x4e, 0x65, 0x77, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x74, 0x61, 0x72, 0x74, 0x5f, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x73, 0x74, 0x61, 0x72, 0x74, 0x42, 0x6c, 0x6f, 0x63,
+	0x6b, 0x12, 0x3f, 0x0a, 0x0c, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x72, 0x6d, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1b, 0x2e, 0x65, 0x74, 0x68, 0x65, 0x72, 0x65,
+	0x75, 0x6d, 0x2e, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x72, 0x6d, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0c, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x72, 0x6d, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x12, 0x24, 0x0a, 0x0d, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x72, 0x6d, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0d, 0x63, 0x6f, 0x6e, 0x66, 0x69,
+	0x72, 0x6d, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x23, 0x0a, 0x0d, 0x72, 0x65, 0x70, 0x6f,
+	0x72, 0x74, 0x5f, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x0c, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x73, 0x22, 0x91, 0x01,
+	0x0a, 0x14, 0x47, 0x65, 0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x74, 0x61, 0x72, 0x74, 0x5f,
+	0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x73, 0x74, 0x61,
+	0x72, 0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x1b, 0x0a, 0x09, 0x65, 0x6e, 0x64, 0x5f, 0x62,
+	0x6c, 0x6f, 0x63, 0x6b, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x65, 0x6e, 0x64, 0x42,
+	0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x23, 0x0a, 0x0d, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x5f, 0x65,
+	0x72, 0x72, 0x6f, 0x72, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x72, 0x65, 0x70,
+	0x6f, 0x72, 0x74, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x71, 0x75, 0x6f,
+	0x72, 0x75, 0x6d, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x71, 0x75, 0x6f, 0x72, 0x75,
+	0x6d, 0x2a, 0x71, 0x0a, 0x11, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x72,
+	0x6d, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1d, 0x0a, 0x19, 0x42, 0x4c, 0x4f, 0x43, 0x4b, 0x5f,
+	0x43, 0x4f, 0x4e, 0x46, 0x49, 0x52, 0x4d, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x4c, 0x41, 0x54,
+	0x45, 0x53, 0x54, 0x10, 0x00, 0x12, 0x1b, 0x0a, 0x17, 0x42, 0x4c, 0x4f, 0x43, 0x4b, 0x5f, 0x43,
+	0x4f, 0x4e, 0x46, 0x49, 0x52, 0x4d, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x53, 0x41, 0x46, 0x45,
+	0x10, 0x01, 0x12, 0x20, 0x0a, 0x1c, 0x42, 0x4c, 0x4f, 0x43, 0x4b, 0x5f, 0x43, 0x4f, 0x4e, 0x46,
+	0x49, 0x52, 0x4d, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x46, 0x49, 0x4e, 0x41, 0x4c, 0x49, 0x5a,
+	0x45, 0x44, 0x10, 0x02, 0x32, 0xcd, 0x02, 0x0a, 0x0f, 0x45, 0x74, 0x68, 0x65, 0x72, 0x65, 0x75,
+	0x6d, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x55, 0x0a, 0x0e, 0x47, 0x65, 0x74, 0x4c,
+	0x61, 0x74, 0x65, 0x73, 0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x1f, 0x2e, 0x65, 0x74, 0x68,
+	0x65, 0x72, 0x65, 0x75, 0x6d, 0x2e, 0x47, 0x65, 0x74, 0x4c, 0x61, 0x74, 0x65, 0x73, 0x74, 0x42,
+	0x6c, 0x6f, 0x63, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x20, 0x2e, 0x65, 0x74,
+	0x68, 0x65, 0x72, 0x65, 0x75, 0x6d, 0x2e, 0x47, 0x65, 0x74, 0x4c, 0x61, 0x74, 0x65, 0x73, 0x74,
+	0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12,
+	0x43, 0x0a, 0x08, 0x47, 0x65, 0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x19, 0x2e, 0x65, 0x74,
+	0x68, 0x65, 0x72, 0x65, 0x75, 0x6d, 0x2e, 0x47, 0x65, 0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x65, 0x74, 0x68, 0x65, 0x72, 0x65, 0x75,
+	0x6d, 0x2e, 0x47, 0x65, 0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x22, 0x00, 0x12, 0x53, 0x0a, 0x12, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62,
+	0x65, 0x4e, 0x65, 0x77, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x12, 0x23, 0x2e, 0x65, 0x74, 0x68,
+	0x65, 0x72, 0x65, 0x75, 0x6d, 0x2e, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x4e,
+	0x65, 0x77, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x14, 0x2e, 0x65, 0x74, 0x68, 0x65, 0x72, 0x65, 0x75, 0x6d, 0x2e, 0x42, 0x6c, 0x6f, 0x63, 0x6b,
+	0x45, 0x76, 0x65, 0x6e, 0x74, 0x22, 0x00, 0x30, 0x01, 0x12, 0x49, 0x0a, 0x0d, 0x47, 0x65, 0x74,
+	0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x1e, 0x2e, 0x65, 0x74, 0x68,
+	0x65, 0x72, 0x65, 0x75, 0x6d, 0x2e, 0x47, 0x65, 0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x52, 0x61,
+	0x6e, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e, 0x65, 0x74, 0x68,
+	0x65, 0x72, 0x65, 0x75, 0x6d, 0x2e, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x45, 0x76, 0x65, 0x6e, 0x74,
+	0x22, 0x00, 0x30, 0x01, 0x42, 0x2e, 0x5a, 0x2c, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63,
+	0x6f, 0x6d, 0x2f, 0x61, 0x6c, 0x30, 0x30, 0x32, 0x2f, 0x73, 0x79, 0x6c, 0x70, 0x68, 0x2f, 0x63,
+	0x68, 0x61, 0x69, 0x6e, 0x73, 0x2f, 0x65, 0x74, 0x68, 0x65, 0x72, 0x65, 0x75, 0x6d, 0x2f, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 })
 
 var (
@@ -320,33 +513,38 @@ func file_ethereum_service_proto_rawDescGZIP() []byte {
 	return file_ethereum_service_proto_rawDescData
 }
 
-var file_ethereum_service_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_ethereum_service_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_ethereum_service_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
 var file_ethereum_service_proto_goTypes = []any{
-	(*GetLatestBlockResponse)(nil),    // 0: ethereum.GetLatestBlockResponse
-	(*GetBlockRequest)(nil),           // 1: ethereum.GetBlockRequest
-	(*GetBlockResponse)(nil),          // 2: ethereum.GetBlockResponse
-	(*SubscribeNewBlocksRequest)(nil), // 3: ethereum.SubscribeNewBlocksRequest
-	(*GetBlockRangeRequest)(nil),      // 4: ethereum.GetBlockRangeRequest
-	(*LatestBlock)(nil),               // 5: ethereum.LatestBlock
-	(*BlockData)(nil),                 // 6: ethereum.BlockData
-	(*emptypb.Empty)(nil),             // 7: google.protobuf.Empty
+	(BlockConfirmation)(0),            // 0: ethereum.BlockConfirmation
+	(*GetLatestBlockRequest)(nil),     // 1: ethereum.GetLatestBlockRequest
+	(*GetLatestBlockResponse)(nil),    // 2: ethereum.GetLatestBlockResponse
+	(*GetBlockRequest)(nil),           // 3: ethereum.GetBlockRequest
+	(*GetBlockResponse)(nil),          // 4: ethereum.GetBlockResponse
+	(*SubscribeNewBlocksRequest)(nil), // 5: ethereum.SubscribeNewBlocksRequest
+	(*GetBlockRangeRequest)(nil),      // 6: ethereum.GetBlockRangeRequest
+	(*LatestBlock)(nil),               // 7: ethereum.LatestBlock
+	(*BlockData)(nil),                 // 8: ethereum.BlockData
+	(*BlockEvent)(nil),                // 9: ethereum.BlockEvent
 }
 var file_ethereum_service_proto_depIdxs = []int32{
-	5, // 0: ethereum.GetLatestBlockResponse.latest_block:type_name -> ethereum.LatestBlock
-	6, // 1: ethereum.GetBlockResponse.block_data:type_name -> ethereum.BlockData
-	7, // 2: ethereum.EthereumService.GetLatestBlock:input_type -> google.protobuf.Empty
-	1, // 3: ethereum.EthereumService.GetBlock:input_type -> ethereum.GetBlockRequest
-	3, // 4: ethereum.EthereumService.SubscribeNewBlocks:input_type -> ethereum.SubscribeNewBlocksRequest
-	4, // 5: ethereum.EthereumService.GetBlockRange:input_type -> ethereum.GetBlockRangeRequest
-	0, // 6: ethereum.EthereumService.GetLatestBlock:output_type -> ethereum.GetLatestBlockResponse
-	2, // 7: ethereum.EthereumService.GetBlock:output_type -> ethereum.GetBlockResponse
-	6, // 8: ethereum.EthereumService.SubscribeNewBlocks:output_type -> ethereum.BlockData
-	6, // 9: ethereum.EthereumService.GetBlockRange:output_type -> ethereum.BlockData
-	6, // [6:10] is the sub-list for method output_type
-	2, // [2:6] is the sub-list for method input_type
-	2, // [2:2] is the sub-list for extension type_name
-	2, // [2:2] is the sub-list for extension extendee
-	0, // [0:2] is the sub-list for field type_name
+	0, // 0: ethereum.GetLatestBlockRequest.confirmation:type_name -> ethereum.BlockConfirmation
+	7, // 1: ethereum.GetLatestBlockResponse.latest_block:type_name -> ethereum.LatestBlock
+	8, // 2: ethereum.GetBlockResponse.block_data:type_name -> ethereum.BlockData
+	0, // 3: ethereum.SubscribeNewBlocksRequest.confirmation:type_name -> ethereum.BlockConfirmation
+	1, // 4: ethereum.EthereumService.GetLatestBlock:input_type -> ethereum.GetLatestBlockRequest
+	3, // 5: ethereum.EthereumService.GetBlock:input_type -> ethereum.GetBlockRequest
+	5, // 6: ethereum.EthereumService.SubscribeNewBlocks:input_type -> ethereum.SubscribeNewBlocksRequest
+	6, // 7: ethereum.EthereumService.GetBlockRange:input_type -> ethereum.GetBlockRangeRequest
+	2, // 8: ethereum.EthereumService.GetLatestBlock:output_type -> ethereum.GetLatestBlockResponse
+	4, // 9: ethereum.EthereumService.GetBlock:output_type -> ethereum.GetBlockResponse
+	9, // 10: ethereum.EthereumService.SubscribeNewBlocks:output_type -> ethereum.BlockEvent
+	9, // 11: ethereum.EthereumService.GetBlockRange:output_type -> ethereum.BlockEvent
+	8, // [8:12] is the sub-list for method output_type
+	4, // [4:8] is the sub-list for method input_type
+	4, // [4:4] is the sub-list for extension type_name
+	4, // [4:4] is the sub-list for extension extendee
+	0, // [0:4] is the sub-list for field type_name
 }
 
 func init() { file_ethereum_service_proto_init() }
@@ -360,13 +558,14 @@ func file_ethereum_service_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_ethereum_service_proto_rawDesc), len(file_ethereum_service_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   5,
+			NumEnums:      1,
+			NumMessages:   6,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_ethereum_service_proto_goTypes,
 		DependencyIndexes: file_ethereum_service_proto_depIdxs,
+		EnumInfos:         file_ethereum_service_proto_enumTypes,
 		MessageInfos:      file_ethereum_service_proto_msgTypes,
 	}.Build()
 	File_ethereum_service_proto = out.File
@@ -11,7 +11,6 @@ import (
 	grpc "google.golang.org/grpc"
 	codes "google.golang.org/grpc/codes"
 	status "google.golang.org/grpc/status"
-	emptypb "google.golang.org/protobuf/types/known/emptypb"
 )
 
 // This is a compile-time assertion to ensure that this generated file
@@ -30,13 +29,13 @@ const (
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type EthereumServiceClient interface {
-	GetLatestBlock(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*GetLatestBlockResponse, error)
+	GetLatestBlock(ctx context.Context, in *GetLatestBlockRequest, opts ...grpc.CallOption) (*GetLatestBlockResponse, error)
 	// Get block by number
 	GetBlock(ctx context.Context, in *GetBlockRequest, opts ...grpc.CallOption) (*GetBlockResponse, error)
 	// Stream new blocks
-	SubscribeNewBlocks(ctx context.Context, in *SubscribeNewBlocksRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[BlockData], error)
+	SubscribeNewBlocks(ctx context.Context, in *SubscribeNewBlocksRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[BlockEvent], error)
 	// Get historical blocks
-	GetBlockRange(ctx context.Context, in *GetBlockRangeRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[BlockData], error)
+	GetBlockRange(ctx context.Context, in *GetBlockRangeRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[BlockEvent], error)
 }
 
 type ethereumServiceClient struct {
@@ -47,7 +46,7 @@ func NewEthereumServiceClient(cc grpc.ClientConnInterface) EthereumServiceClient
 	return &ethereumServiceClient{cc}
 }
 
-func (c *ethereumServiceClient) GetLatestBlock(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*GetLatestBlockResponse, error) {
+func (c *ethereumServiceClient) GetLatestBlock(ctx context.Context, in *GetLatestBlockRequest, opts ...grpc.CallOption) (*GetLatestBlockResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(GetLatestBlockResponse)
 	err := c.cc.Invoke(ctx, EthereumService_GetLatestBlock_FullMethodName, in, out, cOpts...)
@@ -67,13 +66,13 @@ func (c *ethereumServiceClient) GetBlock(ctx context.Context, in *GetBlockReques
 	return out, nil
 }
 
-func (c *ethereumServiceClient) SubscribeNewBlocks(ctx context.Context, in *SubscribeNewBlocksRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[BlockData], error) {
+func (c *ethereumServiceClient) SubscribeNewBlocks(ctx context.Context, in *SubscribeNewBlocksRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[BlockEvent], error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	stream, err := c.cc.NewStream(ctx, &EthereumService_ServiceDesc.Streams[0], EthereumService_SubscribeNewBlocks_FullMethodName, cOpts...)
 	if err != nil {
 		return nil, err
 	}
-	x := &grpc.GenericClientStream[SubscribeNewBlocksRequest, BlockData]{ClientStream: stream}
+	x := &grpc.GenericClientStream[SubscribeNewBlocksRequest, BlockEvent]{ClientStream: stream}
 	if err := x.ClientStream.SendMsg(in); err != nil {
 		return nil, err
 	}
@@ -84,15 +83,15 @@ func (c *ethereumServiceClient) SubscribeNewBlocks(ctx context.Context, in *Subs
 }
 
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
-type EthereumService_SubscribeNewBlocksClient = grpc.ServerStreamingClient[BlockData]
+type EthereumService_SubscribeNewBlocksClient = grpc.ServerStreamingClient[BlockEvent]
 
-func (c *ethereumServiceClient) GetBlockRange(ctx context.Context, in *GetBlockRangeRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[BlockData], error) {
+func (c *ethereumServiceClient) GetBlockRange(ctx context.Context, in *GetBlockRangeRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[BlockEvent], error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	stream, err := c.cc.NewStream(ctx, &EthereumService_ServiceDesc.Streams[1], EthereumService_GetBlockRange_FullMethodName, cOpts...)
 	if err != nil {
 		return nil, err
 	}
-	x := &grpc.GenericClientStream[GetBlockRangeRequest, BlockData]{ClientStream: stream}
+	x := &grpc.GenericClientStream[GetBlockRangeRequest, BlockEvent]{ClientStream: stream}
 	if err := x.ClientStream.SendMsg(in); err != nil {
 		return nil, err
 	}
@@ -103,19 +102,19 @@ func (c *ethereumServiceClient) GetBlockRange(ctx context.Context, in *GetBlockR
 }
 
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
-type EthereumService_GetBlockRangeClient = grpc.ServerStreamingClient[BlockData]
+type EthereumService_GetBlockRangeClient = grpc.ServerStreamingClient[BlockEvent]
 
 // EthereumServiceServer is the server API for EthereumService service.
 // All implementations must embed UnimplementedEthereumServiceServer
 // for forward compatibility.
 type EthereumServiceServer interface {
-	GetLatestBlock(context.Context, *emptypb.Empty) (*GetLatestBlockResponse, error)
+	GetLatestBlock(context.Context, *GetLatestBlockRequest) (*GetLatestBlockResponse, error)
 	// Get block by number
 	GetBlock(context.Context, *GetBlockRequest) (*GetBlockResponse, error)
 	// Stream new blocks
-	SubscribeNewBlocks(*SubscribeNewBlocksRequest, grpc.ServerStreamingServer[BlockData]) error
+	SubscribeNewBlocks(*SubscribeNewBlocksRequest, grpc.ServerStreamingServer[BlockEvent]) error
 	// Get historical blocks
-	GetBlockRange(*GetBlockRangeRequest, grpc.ServerStreamingServer[BlockData]) error
+	GetBlockRange(*GetBlockRangeRequest, grpc.ServerStreamingServer[BlockEvent]) error
 	mustEmbedUnimplementedEthereumServiceServer()
 }
 
@@ -126,16 +125,16 @@ type EthereumServiceServer interface {
 // pointer dereference when methods are called.
 type UnimplementedEthereumServiceServer struct{}
 
-func (UnimplementedEthereumServiceServer) GetLatestBlock(context.Context, *emptypb.Empty) (*GetLatestBlockResponse, error) {
+func (UnimplementedEthereumServiceServer) GetLatestBlock(context.Context, *GetLatestBlockRequest) (*GetLatestBlockResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetLatestBlock not implemented")
 }
 func (UnimplementedEthereumServiceServer) GetBlock(context.Context, *GetBlockRequest) (*GetBlockResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetBlock not implemented")
 }
-func (UnimplementedEthereumServiceServer) SubscribeNewBlocks(*SubscribeNewBlocksRequest, grpc.ServerStreamingServer[BlockData]) error {
+func (UnimplementedEthereumServiceServer) SubscribeNewBlocks(*SubscribeNewBlocksRequest, grpc.ServerStreamingServer[BlockEvent]) error {
 	return status.Errorf(codes.Unimplemented, "method SubscribeNewBlocks not implemented")
 }
-func (UnimplementedEthereumServiceServer) GetBlockRange(*GetBlockRangeRequest, grpc.ServerStreamingServer[BlockData]) error {
+func (UnimplementedEthereumServiceServer) GetBlockRange(*GetBlockRangeRequest, grpc.ServerStreamingServer[BlockEvent]) error {
 	return status.Errorf(codes.Unimplemented, "method GetBlockRange not implemented")
 }
 func (UnimplementedEthereumServiceServer) mustEmbedUnimplementedEthereumServiceServer() {}
@@ -160,7 +159,7 @@ func RegisterEthereumServiceServer(s grpc.ServiceRegistrar, srv EthereumServiceS
 }
 
 func _EthereumService_GetLatestBlock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(emptypb.Empty)
+	in := new(GetLatestBlockRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
@@ -172,7 +171,7 @@ func _EthereumService_GetLatestBlock_Handler(srv interface{}, ctx context.Contex
 		FullMethod: EthereumService_GetLatestBlock_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(EthereumServiceServer).GetLatestBlock(ctx, req.(*emptypb.Empty))
+		return srv.(EthereumServiceServer).GetLatestBlock(ctx, req.(*GetLatestBlockRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -200,22 +199,22 @@ func _EthereumService_SubscribeNewBlocks_Handler(srv interface{}, stream grpc.Se
 	if err := stream.RecvMsg(m); err != nil {
 		return err
 	}
-	return srv.(EthereumServiceServer).SubscribeNewBlocks(m, &grpc.GenericServerStream[SubscribeNewBlocksRequest, BlockData]{ServerStream: stream})
+	return srv.(EthereumServiceServer).SubscribeNewBlocks(m, &grpc.GenericServerStream[SubscribeNewBlocksRequest, BlockEvent]{ServerStream: stream})
 }
 
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
-type EthereumService_SubscribeNewBlocksServer = grpc.ServerStreamingServer[BlockData]
+type EthereumService_SubscribeNewBlocksServer = grpc.ServerStreamingServer[BlockEvent]
 
 func _EthereumService_GetBlockRange_Handler(srv interface{}, stream grpc.ServerStream) error {
 	m := new(GetBlockRangeRequest)
 	if err := stream.RecvMsg(m); err != nil {
 		return err
 	}
-	return srv.(EthereumServiceServer).GetBlockRange(m, &grpc.GenericServerStream[GetBlockRangeRequest, BlockData]{ServerStream: stream})
+	return srv.(EthereumServiceServer).GetBlockRange(m, &grpc.GenericServerStream[GetBlockRangeRequest, BlockEvent]{ServerStream: stream})
 }
 
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
-type EthereumService_GetBlockRangeServer = grpc.ServerStreamingServer[BlockData]
+type EthereumService_GetBlockRangeServer = grpc.ServerStreamingServer[BlockEvent]
 
 // EthereumService_ServiceDesc is the grpc.ServiceDesc for EthereumService service.
 // It's only intended for direct use with grpc.RegisterService,
@@ -629,6 +629,230 @@ func (x *BlockData) GetTokenTransfers() []*TokenTransfer {
 	return nil
 }
 
+type BlockRemoved struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BlockNumber   int64                  `protobuf:"varint,1,opt,name=block_number,json=blockNumber,proto3" json:"block_number,omitempty"`
+	Hash          string                 `protobuf:"bytes,2,opt,name=hash,proto3" json:"hash,omitempty"`
+	ParentHash    string                 `protobuf:"bytes,3,opt,name=parent_hash,json=parentHash,proto3" json:"parent_hash,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BlockRemoved) Reset() {
+	*x = BlockRemoved{}
+	mi := &file_ethereum_types_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BlockRemoved) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BlockRemoved) ProtoMessage() {}
+
+func (x *BlockRemoved) ProtoReflect() protoreflect.Message {
+	mi := &file_ethereum_types_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BlockRemoved.ProtoReflect.Descriptor instead.
+func (*BlockRemoved) Descriptor() ([]byte, []int) {
+	return file_ethereum_types_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *BlockRemoved) GetBlockNumber() int64 {
+	if x != nil {
+		return x.BlockNumber
+	}
+	return 0
+}
+
+func (x *BlockRemoved) GetHash() string {
+	if x != nil {
+		return x.Hash
+	}
+	return ""
+}
+
+func (x *BlockRemoved) GetParentHash() string {
+	if x != nil {
+		return x.ParentHash
+	}
+	return ""
+}
+
+// BlockError reports a block that could not be fetched.
+type BlockError struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	BlockNumber int64                  `protobuf:"varint,1,opt,name=block_number,json=blockNumber,proto3" json:"block_number,omitempty"`
+	Reason      string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	// Whether fetching the block again may succeed.
+	Retryable     bool `protobuf:"varint,3,opt,name=retryable,proto3" json:"retryable,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BlockError) Reset() {
+	*x = BlockError{}
+	mi := &file_ethereum_types_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BlockError) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BlockError) ProtoMessage() {}
+
+func (x *BlockError) ProtoReflect() protoreflect.Message {
+	mi := &file_ethereum_types_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BlockError.ProtoReflect.Descriptor instead.
+func (*BlockError) Descriptor() ([]byte, []int) {
+	return file_ethereum_types_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *BlockError) GetBlockNumber() int64 {
+	if x != nil {
+		return x.BlockNumber
+	}
+	return 0
+}
+
+func (x *BlockError) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *BlockError) GetRetryable() bool {
+	if x != nil {
+		return x.Retryable
+	}
+	return false
+}
+
+// BlockEvent is a single message of a block stream. When the chain
+// reorganizes, a block_removed event is sent for each orphaned block, newest
+// first, before the canonical replacements are sent as block_data. A
+// block_error is only sent to streams that asked for errors to be reported.
+type BlockEvent struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Event:
+	//
+	//	*BlockEvent_BlockData
+	//	*BlockEvent_BlockRemoved
+	//	*BlockEvent_BlockError
+	Event         isBlockEvent_Event `protobuf_oneof:"event"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BlockEvent) Reset() {
+	*x = BlockEvent{}
+	mi := &file_ethereum_types_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BlockEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BlockEvent) ProtoMessage() {}
+
+func (x *BlockEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_ethereum_types_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BlockEvent.ProtoReflect.Descriptor instead.
+func (*BlockEvent) Descriptor() ([]byte, []int) {
+	return file_ethereum_types_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *BlockEvent) GetEvent() isBlockEvent_Event {
+	if x != nil {
+		return x.Event
+	}
+	return nil
+}
+
+func (x *BlockEvent) GetBlockData() *BlockData {
+	if x != nil {
+		if x, ok := x.Event.(*BlockEvent_BlockData); ok {
+			return x.BlockData
+		}
+	}
+	return nil
+}
+
+func (x *BlockEvent) GetBlockRemoved() *BlockRemoved {
+	if x != nil {
+		if x, ok := x.Event.(*BlockEvent_BlockRemoved); ok {
+			return x.BlockRemoved
+		}
+	}
+	return nil
+}
+
+func (x *BlockEvent) GetBlockError() *BlockError {
+	if x != nil {
+		if x, ok := x.Event.(*BlockEvent_BlockError); ok {
+			return x.BlockError
+		}
+	}
+	return nil
+}
+
+type isBlockEvent_Event interface {
+	isBlockEvent_Event()
+}
+
+type BlockEvent_BlockData struct {
+	BlockData *BlockData `protobuf:"bytes,1,opt,name=block_data,json=blockData,proto3,oneof"`
+}
+
+type BlockEvent_BlockRemoved struct {
+	BlockRemoved *BlockRemoved `protobuf:"bytes,2,opt,name=block_removed,json=blockRemoved,proto3,oneof"`
+}
+
+type BlockEvent_BlockError struct {
+	BlockError *BlockError `protobuf:"bytes,3,opt,name=block_error,json=blockError,proto3,oneof"`
+}
+
+func (*BlockEvent_BlockData) isBlockEvent_Event() {}
+
+func (*BlockEvent_BlockRemoved) isBlockEvent_Event() {}
+
+func (*BlockEvent_BlockError) isBlockEvent_Event() {}
+
 var File_ethereum_types_proto protoreflect.FileDescriptor
 
 var file_ethereum_types_proto_rawDesc = string([]byte{
@@ -740,11 +964,36 @@ var file_ethereum_types_proto_rawDesc = string([]byte{
 	0x5f, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b,
 	0x32, 0x17, 0x2e, 0x65, 0x74, 0x68, 0x65, 0x72, 0x65, 0x75, 0x6d, 0x2e, 0x54, 0x6f, 0x6b, 0x65,
 	0x6e, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x52, 0x0e, 0x74, 0x6f, 0x6b, 0x65, 0x6e,
-	0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x73, 0x42, 0x2e, 0x5a, 0x2c, 0x67, 0x69, 0x74,
-	0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x61, 0x6c, 0x30, 0x30, 0x32, 0x2f, 0x73, 0x79,
-	0x6c, 0x70, 0x68, 0x2f, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x73, 0x2f, 0x65, 0x74, 0x68, 0x65, 0x72,
-	0x65, 0x75, 0x6d, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x33,
+	0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x73, 0x22, 0x66, 0x0a, 0x0c, 0x42, 0x6c, 0x6f,
+	0x63, 0x6b, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x64, 0x12, 0x21, 0x0a, 0x0c, 0x62, 0x6c, 0x6f,
+	0x63, 0x6b, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x0b, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x12, 0x0a, 0x04,
+	0x68, 0x61, 0x73, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x68, 0x61, 0x73, 0x68,
+	0x12, 0x1f, 0x0a, 0x0b, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x48, 0x61, 0x73,
+	0x68, 0x22, 0x65, 0x0a, 0x0a, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x12,
+	0x21, 0x0a, 0x0c, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x4e, 0x75, 0x6d, 0x62,
+	0x65, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x12, 0x1c, 0x0a, 0x09, 0x72, 0x65,
+	0x74, 0x72, 0x79, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x72,
+	0x65, 0x74, 0x72, 0x79, 0x61, 0x62, 0x6c, 0x65, 0x22, 0xc3, 0x01, 0x0a, 0x0a, 0x42, 0x6c, 0x6f,
+	0x63, 0x6b, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x34, 0x0a, 0x0a, 0x62, 0x6c, 0x6f, 0x63, 0x6b,
+	0x5f, 0x64, 0x61, 0x74, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x65, 0x74,
+	0x68, 0x65, 0x72, 0x65, 0x75, 0x6d, 0x2e, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x44, 0x61, 0x74, 0x61,
+	0x48, 0x00, 0x52, 0x09, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x44, 0x61, 0x74, 0x61, 0x12, 0x3d, 0x0a,
+	0x0d, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x72, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x64, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x65, 0x74, 0x68, 0x65, 0x72, 0x65, 0x75, 0x6d, 0x2e,
+	0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x64, 0x48, 0x00, 0x52, 0x0c,
+	0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x64, 0x12, 0x37, 0x0a, 0x0b,
+	0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x14, 0x2e, 0x65, 0x74, 0x68, 0x65, 0x72, 0x65, 0x75, 0x6d, 0x2e, 0x42, 0x6c, 0x6f,
+	0x63, 0x6b, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x48, 0x00, 0x52, 0x0a, 0x62, 0x6c, 0x6f, 0x63, 0x6b,
+	0x45, 0x72, 0x72, 0x6f, 0x72, 0x42, 0x07, 0x0a, 0x05, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x42, 0x2e,
+	0x5a, 0x2c, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x61, 0x6c, 0x30,
+	0x30, 0x32, 0x2f, 0x73, 0x79, 0x6c, 0x70, 0x68, 0x2f, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x73, 0x2f,
+	0x65, 0x74, 0x68, 0x65, 0x72, 0x65, 0x75, 0x6d, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 })
 
 var (
@@ -759,7 +1008,7 @@ func file_ethereum_types_proto_rawDescGZIP() []byte {
 	return file_ethereum_types_proto_rawDescData
 }
 
-var file_ethereum_types_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_ethereum_types_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
 var file_ethereum_types_proto_goTypes = []any{
 	(*LatestBlock)(nil),   // 0: ethereum.LatestBlock
 	(*Block)(nil),         // 1: ethereum.Block
@@ -767,17 +1016,23 @@ var file_ethereum_types_proto_goTypes = []any{
 	(*Log)(nil),           // 3: ethereum.Log
 	(*TokenTransfer)(nil), // 4: ethereum.TokenTransfer
 	(*BlockData)(nil),     // 5: ethereum.BlockData
+	(*BlockRemoved)(nil),  // 6: ethereum.BlockRemoved
+	(*BlockError)(nil),    // 7: ethereum.BlockError
+	(*BlockEvent)(nil),    // 8: ethereum.BlockEvent
 }
 var file_ethereum_types_proto_depIdxs = []int32{
 	1, // 0: ethereum.BlockData.block:type_name -> ethereum.Block
 	2, // 1: ethereum.BlockData.transactions:type_name -> ethereum.Transaction
 	3, // 2: ethereum.BlockData.logs:type_name -> ethereum.Log
 	4, // 3: ethereum.BlockData.token_transfers:type_name -> ethereum.TokenTransfer
-	4, // [4:4] is the sub-list for method output_type
-	4, // [4:4] is the sub-list for method input_type
-	4, // [4:4] is the sub-list for extension type_name
-	4, // [4:4] is the sub-list for extension extendee
-	0, // [0:4] is the sub-list for field type_name
+	5, // 4: ethereum.BlockEvent.block_data:type_name -> ethereum.BlockData
+	6, // 5: ethereum.BlockEvent.block_removed:type_name -> ethereum.BlockRemoved
+	7, // 6: ethereum.BlockEvent.block_error:type_name -> ethereum.BlockError
+	7, // [7:7] is the sub-list for method output_type
+	7, // [7:7] is the sub-list for method input_type
+	7, // [7:7] is the sub-list for extension type_name
+	7, // [7:7] is the sub-list for extension extendee
+	0, // [0:7] is the sub-list for field type_name
 }
 
 func init() { file_ethereum_types_proto_init() }
@@ -785,13 +1040,18 @@ func file_ethereum_types_proto_init() {
 	if File_ethereum_types_proto != nil {
 		return
 	}
+	file_ethereum_types_proto_msgTypes[8].OneofWrappers = []any{
+		(*BlockEvent_BlockData)(nil),
+		(*BlockEvent_BlockRemoved)(nil),
+		(*BlockEvent_BlockError)(nil),
+	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_ethereum_types_proto_rawDesc), len(file_ethereum_types_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   6,
+			NumMessages:   9,
 			NumExtensions: 0,
 			NumServices:   0,
 		},